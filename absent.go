@@ -0,0 +1,34 @@
+package optional
+
+// AbsentError is the value Get and OrElseThrow panic with when the Optional
+// is empty, so that middleware recovering panics can tell absent Optionals
+// apart from other crashes:
+//
+//	if err, ok := r.(error); ok && errors.Is(err, optional.ErrNotPresent) {
+//		http.Error(w, "not found", http.StatusNotFound)
+//	}
+//
+// It matches ErrNotPresent with errors.Is, as well as the error passed to
+// OrElseThrow.
+type AbsentError struct {
+	// Err is the error passed to OrElseThrow, or nil for Get.
+	Err error
+	// origin describes where the empty Optional was created, when known.
+	origin string
+}
+
+// Error returns the message of Err, or describes the failed Get.
+func (e *AbsentError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return "Optional.Get: no value present" + e.origin
+}
+
+// Unwrap returns ErrNotPresent and Err.
+func (e *AbsentError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrNotPresent}
+	}
+	return []error{ErrNotPresent, e.Err}
+}
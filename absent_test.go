@@ -0,0 +1,41 @@
+package optional
+
+import (
+	"errors"
+	"testing"
+)
+
+var errMissingUser = errors.New("user not found")
+
+func recoverError(f func()) (err error) {
+	defer func() {
+		err, _ = recover().(error)
+	}()
+	f()
+	return nil
+}
+
+func TestAbsentErrorFromGet(t *testing.T) {
+	err := recoverError(func() { Empty[int]().Get() })
+	var absent *AbsentError
+	if !errors.As(err, &absent) || absent.Err != nil {
+		t.Fatalf("Expected Get to panic with an *AbsentError, but got %v", err)
+	}
+	if !errors.Is(err, ErrNotPresent) {
+		t.Errorf("Expected the panic to match ErrNotPresent, but it did not")
+	}
+}
+
+func TestAbsentErrorFromOrElseThrow(t *testing.T) {
+	err := recoverError(func() { Empty[int]().OrElseThrow(errMissingUser) })
+	var absent *AbsentError
+	if !errors.As(err, &absent) || absent.Err != errMissingUser {
+		t.Fatalf("Expected OrElseThrow to panic with an *AbsentError wrapping the error, but got %v", err)
+	}
+	if !errors.Is(err, errMissingUser) || !errors.Is(err, ErrNotPresent) {
+		t.Errorf("Expected the panic to match both errors, but it did not")
+	}
+	if err.Error() != "user not found" {
+		t.Errorf("Expected the message of the wrapped error, but got %q", err.Error())
+	}
+}
@@ -0,0 +1,103 @@
+package optional
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// OptionalAny is a non-generic Optional holding a value of any type, for
+// plugin systems, scripting bridges and reflection-heavy code where the
+// type of the value is not known at compile time. The zero OptionalAny is
+// empty. Convert from and to an Optional[T] with Optional.Any and FromAny.
+type OptionalAny struct {
+	present bool
+	value   any
+}
+
+// AnyOf creates an OptionalAny containing value, which may be nil.
+func AnyOf(value any) OptionalAny {
+	return OptionalAny{present: true, value: value}
+}
+
+// AnyEmpty creates an empty OptionalAny.
+func AnyEmpty() OptionalAny {
+	return OptionalAny{}
+}
+
+// Any converts o into an OptionalAny.
+func (o Optional[T]) Any() OptionalAny {
+	if o.IsEmpty() {
+		return OptionalAny{}
+	}
+	return AnyOf(*o.value)
+}
+
+// FromAny converts a into an Optional[T]. It fails if a holds a value
+// that is not a T, or holds nil and T is not a pointer, map, slice,
+// channel, function or interface type.
+func FromAny[T any](a OptionalAny) (Optional[T], error) {
+	if !a.present {
+		return Empty[T](), nil
+	}
+	v, ok := a.value.(T)
+	switch {
+	case a.value == nil && !isNilValue(reflect.ValueOf(&v).Elem()):
+		return Empty[T](), fmt.Errorf("optional: OptionalAny holds nil, not %s", reflect.TypeFor[T]())
+	case !ok && a.value != nil:
+		return Empty[T](), fmt.Errorf("optional: OptionalAny holds %T, not %s", a.value, reflect.TypeFor[T]())
+	}
+	return Optional[T]{value: &v}, nil
+}
+
+// IsPresent returns true if the OptionalAny contains a value.
+func (a OptionalAny) IsPresent() bool {
+	return a.present
+}
+
+// IsEmpty returns true if the OptionalAny does not contain a value.
+func (a OptionalAny) IsEmpty() bool {
+	return !a.present
+}
+
+// Get returns the value if present, otherwise it panics with an
+// *AbsentError.
+func (a OptionalAny) Get() any {
+	if !a.present {
+		panic(&AbsentError{})
+	}
+	return a.value
+}
+
+// OrElse returns the value if present, otherwise returns other.
+func (a OptionalAny) OrElse(other any) any {
+	if !a.present {
+		return other
+	}
+	return a.value
+}
+
+// Type returns the dynamic type of the value, or nil if the OptionalAny is
+// empty or holds nil.
+func (a OptionalAny) Type() reflect.Type {
+	return reflect.TypeOf(a.value)
+}
+
+// String returns a string representation of the OptionalAny, in the format
+// of Optional.String.
+func (a OptionalAny) String() string {
+	if !a.present {
+		return "Optional.empty"
+	}
+	return fmt.Sprintf("Optional[%v]", a.value)
+}
+
+// MarshalJSON encodes the value, or null when the OptionalAny is empty.
+func (a OptionalAny) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.value)
+}
+
+// IsZero returns true if the OptionalAny is empty.
+func (a OptionalAny) IsZero() bool {
+	return !a.present
+}
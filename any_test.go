@@ -0,0 +1,63 @@
+package optional
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestOptionalAny(t *testing.T) {
+	a := Of(42).Any()
+	if !a.IsPresent() || a.Get() != 42 || a.Type().String() != "int" {
+		t.Errorf("Expected a present 42, but got %v", a)
+	}
+	if e := Empty[int]().Any(); e.IsPresent() || e.OrElse("x") != "x" || e.Type() != nil {
+		t.Errorf("Expected an empty OptionalAny, but got %v", e)
+	}
+	if s := AnyOf("a").String(); s != "Optional[a]" {
+		t.Errorf("Expected Optional[a], but got %s", s)
+	}
+	if s := AnyEmpty().String(); s != "Optional.empty" {
+		t.Errorf("Expected Optional.empty, but got %s", s)
+	}
+}
+
+func TestFromAny(t *testing.T) {
+	if o, err := FromAny[int](AnyOf(42)); err != nil || o.OrZero() != 42 {
+		t.Errorf("Expected Optional[42], but got %v, %v", o, err)
+	}
+	if o, err := FromAny[int](AnyEmpty()); err != nil || o.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v, %v", o, err)
+	}
+	if o, err := FromAny[error](AnyOf(nil)); err != nil || !o.IsPresent() {
+		t.Errorf("Expected a present nil error, but got %v, %v", o, err)
+	}
+	if _, err := FromAny[int](AnyOf("42")); err == nil {
+		t.Errorf("Expected an error for a string, but got nil")
+	}
+	if o, err := FromAny[*int](AnyOf(nil)); err != nil || !o.IsPresent() || o.Get() != nil {
+		t.Errorf("Expected a present nil pointer, but got %v, %v", o, err)
+	}
+	if o, err := FromAny[int](AnyOf(nil)); err == nil || o.IsPresent() {
+		t.Errorf("Expected an error for nil, but got %v, %v", o, err)
+	}
+}
+
+func TestOptionalAnyGetEmpty(t *testing.T) {
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrNotPresent) {
+			t.Errorf("Expected Get to panic with ErrNotPresent, but got %v", err)
+		}
+	}()
+	AnyEmpty().Get()
+}
+
+func TestOptionalAnyJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		A OptionalAny `json:"a"`
+		B OptionalAny `json:"b,omitzero"`
+	}{A: AnyOf(map[string]int{"x": 1})})
+	if err != nil || string(data) != `{"a":{"x":1}}` {
+		t.Errorf("Expected {\"a\":{\"x\":1}}, but got %s, %v", data, err)
+	}
+}
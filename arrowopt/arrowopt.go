@@ -0,0 +1,79 @@
+// Package arrowopt converts between slices of Optionals and Apache Arrow
+// arrays, whose validity bitmaps mark nulls, so columnar code can move
+// between row-oriented Optionals and Arrow without managing null masks:
+//
+//	ages := arrowopt.FromArray[int64](col.(*array.Int64))
+//
+//	b := array.NewInt64Builder(memory.DefaultAllocator)
+//	arrowopt.Append(b, ages)
+//	arr := b.NewArray()
+//
+// The package relies only on the methods shared by the typed arrays and
+// builders of github.com/apache/arrow-go, such as *array.Int64 and
+// *array.Int64Builder, so it does not import Arrow itself.
+package arrowopt
+
+import "github.com/hermann-craft/optional"
+
+// Array is the method set of the typed Arrow arrays holding values of
+// type T, such as *array.Int64 or *array.String.
+type Array[T any] interface {
+	Len() int
+	IsNull(i int) bool
+	Value(i int) T
+}
+
+// Builder is the method set of the typed Arrow builders for values of type
+// T, such as *array.Int64Builder or *array.StringBuilder.
+type Builder[T any] interface {
+	Append(v T)
+	AppendNull()
+}
+
+// BulkBuilder is implemented by the typed Arrow builders that append a
+// slice of values with a validity mask at once.
+type BulkBuilder[T any] interface {
+	AppendValues(v []T, valid []bool)
+}
+
+// FromArray returns the elements of a, with an empty Optional for each
+// null.
+func FromArray[T any, A Array[T]](a A) []optional.Optional[T] {
+	values := make([]optional.Optional[T], a.Len())
+	for i := range values {
+		if a.IsNull(i) {
+			values[i] = optional.Empty[T]()
+			continue
+		}
+		values[i] = optional.Of(a.Value(i))
+	}
+	return values
+}
+
+// Append appends values to b, appending a null for each empty Optional.
+// Builders implementing BulkBuilder receive all values in one call.
+func Append[T any, B Builder[T]](b B, values []optional.Optional[T]) {
+	if bulk, ok := any(b).(BulkBuilder[T]); ok {
+		bulk.AppendValues(Split(values))
+		return
+	}
+	for _, o := range values {
+		if o.IsEmpty() {
+			b.AppendNull()
+			continue
+		}
+		b.Append(o.Get())
+	}
+}
+
+// Split returns the values held by the Optionals, with the zero value for
+// empty ones, together with the validity mask Arrow builders take in
+// AppendValues.
+func Split[T any](values []optional.Optional[T]) ([]T, []bool) {
+	v := make([]T, len(values))
+	valid := make([]bool, len(values))
+	for i, o := range values {
+		v[i], valid[i] = o.OrZero(), o.IsPresent()
+	}
+	return v, valid
+}
@@ -0,0 +1,59 @@
+package arrowopt
+
+import (
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+// int64Array mirrors *array.Int64 and builder *array.Int64Builder.
+type int64Array struct {
+	values []int64
+	valid  []bool
+}
+
+func (a *int64Array) Len() int          { return len(a.values) }
+func (a *int64Array) IsNull(i int) bool { return !a.valid[i] }
+func (a *int64Array) Value(i int) int64 { return a.values[i] }
+func (a *int64Array) Append(v int64)    { a.values, a.valid = append(a.values, v), append(a.valid, true) }
+func (a *int64Array) AppendNull()       { a.values, a.valid = append(a.values, 0), append(a.valid, false) }
+
+// bulkArray also mirrors the AppendValues method of the typed builders.
+type bulkArray struct {
+	int64Array
+	bulkCalls int
+}
+
+func (a *bulkArray) AppendValues(v []int64, valid []bool) {
+	a.bulkCalls++
+	a.values, a.valid = append(a.values, v...), append(a.valid, valid...)
+}
+
+func TestFromArray(t *testing.T) {
+	got := FromArray[int64](&int64Array{values: []int64{1, 0, 3}, valid: []bool{true, false, true}})
+	want := []optional.Optional[int64]{optional.Of[int64](1), optional.Empty[int64](), optional.Of[int64](3)}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, but got %v", want, got)
+	}
+	for i := range want {
+		if !optional.Equal(got[i], want[i]) {
+			t.Errorf("Expected %v at %d, but got %v", want[i], i, got[i])
+		}
+	}
+}
+
+func TestAppend(t *testing.T) {
+	values := []optional.Optional[int64]{optional.Of[int64](1), optional.Empty[int64](), optional.Of[int64](3)}
+
+	var b int64Array
+	Append(&b, values)
+	if got := FromArray[int64](&b); len(got) != 3 || got[0].OrZero() != 1 || got[1].IsPresent() || got[2].OrZero() != 3 {
+		t.Errorf("Expected the values to round-trip, but got %v", got)
+	}
+
+	var bulk bulkArray
+	Append(&bulk, values)
+	if bulk.bulkCalls != 1 || len(bulk.values) != 3 || bulk.valid[1] {
+		t.Errorf("Expected one AppendValues call with a null at 1, but got %+v", bulk)
+	}
+}
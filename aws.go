@@ -0,0 +1,27 @@
+package optional
+
+// FromAWS converts an optional field of an AWS SDK struct, which uses a nil
+// pointer for "not set", into an Optional holding a copy of the pointed-to
+// value. It is the Optional counterpart of aws.ToString and friends.
+func FromAWS[T any](p *T) Optional[T] {
+	if p == nil {
+		return Empty[T]()
+	}
+	v := *p
+	return Optional[T]{value: &v}
+}
+
+// ToAWS returns a pointer to a copy of the value, or nil if o is empty, for
+// assigning to AWS SDK request structs like aws.String does:
+//
+//	input := &s3.PutObjectInput{
+//		Bucket:       aws.String(bucket),
+//		CacheControl: cacheControl.ToAWS(),
+//	}
+func (o Optional[T]) ToAWS() *T {
+	if o.value == nil {
+		return nil
+	}
+	v := *o.value
+	return &v
+}
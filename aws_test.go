@@ -0,0 +1,30 @@
+package optional
+
+import "testing"
+
+func TestFromAWS(t *testing.T) {
+	s := "bucket"
+	o := FromAWS(&s)
+	s = "changed"
+	if o.OrElse("") != "bucket" {
+		t.Errorf("Expected Optional[bucket], but got %v", o)
+	}
+	if got := FromAWS[int32](nil); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestToAWS(t *testing.T) {
+	o := Of(int64(3))
+	p := o.ToAWS()
+	if p == nil || *p != 3 {
+		t.Fatalf("Expected a pointer to 3, but got %v", p)
+	}
+	*p = 4
+	if o.Get() != 3 {
+		t.Errorf("Expected the Optional to be unchanged, but got %v", o)
+	}
+	if got := Empty[string]().ToAWS(); got != nil {
+		t.Errorf("Expected nil, but got %v", got)
+	}
+}
@@ -0,0 +1,37 @@
+// Package binding connects Optional fields to the request binding and
+// validation of web frameworks such as Gin and Echo.
+//
+// Optional implements UnmarshalJSON and the UnmarshalParam method both
+// frameworks use for query, path and form parameters, so request structs
+// with Optional fields bind without further setup, and fields that were not
+// sent stay empty. Struct tag validation with go-playground/validator, which
+// Gin uses for its binding tags, needs the Optional types registered with
+// ValidatorValue:
+//
+//	v := gin_binding.Validator.Engine().(*validator.Validate)
+//	v.RegisterCustomTypeFunc(binding.ValidatorValue,
+//		optional.Optional[string]{}, optional.Optional[int]{})
+//
+// Tags such as `binding:"omitempty,min=3"` then apply to the value held by
+// the Optional, and `binding:"required"` fails for an empty one.
+package binding
+
+import (
+	"reflect"
+
+	"github.com/hermann-craft/optional/reflectopt"
+)
+
+// ValidatorValue is a validator.CustomTypeFunc returning the value held by
+// an Optional field, or nil if it is empty. Other values are returned as
+// they are.
+func ValidatorValue(field reflect.Value) any {
+	if !reflectopt.IsOptionalType(field.Type()) {
+		return field.Interface()
+	}
+	v, ok := reflectopt.ValueOf(field)
+	if !ok {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,49 @@
+package binding
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+func TestValidatorValue(t *testing.T) {
+	if got := ValidatorValue(reflect.ValueOf(optional.Of("abc"))); got != "abc" {
+		t.Errorf("Expected abc, but got %v", got)
+	}
+	if got := ValidatorValue(reflect.ValueOf(optional.Empty[int]())); got != nil {
+		t.Errorf("Expected nil, but got %v", got)
+	}
+	if got := ValidatorValue(reflect.ValueOf(42)); got != 42 {
+		t.Errorf("Expected 42, but got %v", got)
+	}
+}
+
+func TestBindQuery(t *testing.T) {
+	var req struct {
+		Page  optional.Optional[int]    `form:"page"`
+		Query optional.Optional[string] `form:"q"`
+		Debug optional.Optional[bool]   `form:"debug"`
+	}
+	if err := BindQuery(&req, url.Values{"page": {"2"}, "q": {"go"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if req.Page.OrElse(0) != 2 || req.Query.OrElse("") != "go" || req.Debug.IsPresent() {
+		t.Errorf("Expected page 2, query go and no debug, but got %v %v %v", req.Page, req.Query, req.Debug)
+	}
+	if err := BindQuery(&req, url.Values{"page": {"two"}}); err == nil {
+		t.Error("Expected an error for an invalid page, but got nil")
+	}
+}
+
+func TestUnmarshalParam(t *testing.T) {
+	// Gin and Echo bind query, path and form parameters with UnmarshalParam.
+	var page optional.Optional[int]
+	if err := page.UnmarshalParam("2"); err != nil || page.OrZero() != 2 {
+		t.Errorf("Expected Optional[2], but got %v (%v)", page, err)
+	}
+	if err := page.UnmarshalParam("two"); err == nil {
+		t.Error("Expected an error for an invalid page, but got nil")
+	}
+}
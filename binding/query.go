@@ -0,0 +1,54 @@
+package binding
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+)
+
+// BindQuery binds the query parameters of a net/http request to the fields
+// of the struct dst points to, for handlers that do not use a framework.
+// Parameters are matched by the form tag of a field, or else its name, and
+// decoded with UnmarshalParams, which Optional implements, so repeated
+// parameters bind to Optional slices; fields implementing only
+// UnmarshalParam receive the first value. Fields whose parameter was not
+// sent are left untouched, and fields implementing neither method are
+// skipped.
+//
+//	var req struct {
+//		Tags optional.Optional[[]string] `form:"tag"`
+//		Page optional.Optional[int]      `form:"page"`
+//	}
+//	err := binding.BindQuery(&req, r.URL.Query())
+func BindQuery(dst any, query url.Values) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("binding: BindQuery needs a pointer to a struct, not %T", dst)
+	}
+	v = v.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("form")
+		if name == "" {
+			name = f.Name
+		}
+		values, ok := query[name]
+		if !f.IsExported() || name == "-" || !ok {
+			continue
+		}
+		var err error
+		switch u := v.Field(i).Addr().Interface().(type) {
+		case interface{ UnmarshalParams([]string) error }:
+			err = u.UnmarshalParams(values)
+		case interface{ UnmarshalParam(string) error }:
+			if len(values) > 0 {
+				err = u.UnmarshalParam(values[0])
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("binding: parameter %s: %w", name, err)
+		}
+	}
+	return nil
+}
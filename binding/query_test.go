@@ -0,0 +1,42 @@
+package binding
+
+import (
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+func TestBindQueryRepeated(t *testing.T) {
+	var req struct {
+		Tags  optional.Optional[[]string] `form:"tag"`
+		IDs   optional.Optional[[]int]    `form:"id"`
+		Empty optional.Optional[[]string] `form:"empty"`
+		Page  optional.Optional[int]      `form:"page"`
+		Sort  optional.Optional[string]
+	}
+	query := url.Values{"tag": {"a", "b"}, "empty": {""}, "page": {"2", "3"}, "Sort": {"name"}}
+	if err := BindQuery(&req, query); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !slices.Equal(req.Tags.OrZero(), []string{"a", "b"}) {
+		t.Errorf("Expected tags [a b], but got %v", req.Tags)
+	}
+	if req.IDs.IsPresent() {
+		t.Errorf("Expected no ids, but got %v", req.IDs)
+	}
+	if !req.Empty.IsPresent() || len(req.Empty.Get()) != 0 {
+		t.Errorf("Expected a present empty slice, but got %v", req.Empty)
+	}
+	if req.Page.OrZero() != 2 || req.Sort.OrZero() != "name" {
+		t.Errorf("Expected page 2 and sort name, but got %v and %v", req.Page, req.Sort)
+	}
+
+	if err := BindQuery(&req, url.Values{"id": {"1", "two"}}); err == nil {
+		t.Errorf("Expected an error for an invalid id, but got nil")
+	}
+	if err := BindQuery(req, query); err == nil {
+		t.Errorf("Expected an error for a non-pointer, but got nil")
+	}
+}
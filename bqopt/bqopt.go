@@ -0,0 +1,121 @@
+// Package bqopt connects Optionals to cloud.google.com/go/bigquery for
+// analytics ingestion pipelines.
+//
+// From and To convert between Optional and the Null types of the bigquery
+// package, such as bigquery.NullInt64, bigquery.NullString and
+// bigquery.NullTimestamp. All of them are structs holding the value and a
+// Valid flag, which the functions access by reflection, so the package does
+// not import the bigquery client itself:
+//
+//	n, err := bqopt.To[bigquery.NullInt64](user.Age)
+//	age, err := bqopt.From[int64](row.Age)
+//
+// Row encodes a struct of Optional fields as a row whose empty Optionals are
+// NULL, which makes ValueSaver implementations one-liners:
+//
+//	func (e Event) Save() (map[string]bigquery.Value, string, error) {
+//		row := map[string]bigquery.Value{}
+//		for k, v := range bqopt.Row(e) {
+//			row[k] = v
+//		}
+//		return row, e.ID, nil
+//	}
+package bqopt
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hermann-craft/optional"
+	"github.com/hermann-craft/optional/reflectopt"
+)
+
+// From converts a bigquery Null value holding a T into an Optional. It
+// returns an error if n is not such a value.
+func From[T any](n any) (optional.Optional[T], error) {
+	v := reflect.ValueOf(n)
+	value, valid, err := nullFields(reflect.TypeOf(n))
+	if err != nil {
+		return optional.Empty[T](), err
+	}
+	x, ok := v.Field(value).Interface().(T)
+	if !ok {
+		return optional.Empty[T](), fmt.Errorf("bqopt: %s does not hold a %s", v.Type(), reflect.TypeFor[T]())
+	}
+	if !v.Field(valid).Bool() {
+		return optional.Empty[T](), nil
+	}
+	return optional.Of(x), nil
+}
+
+// To converts an Optional into the bigquery Null type N, which must hold a
+// value of type T. It returns an error if N is not such a type.
+func To[N, T any](o optional.Optional[T]) (N, error) {
+	var n N
+	v := reflect.ValueOf(&n).Elem()
+	value, valid, err := nullFields(v.Type())
+	if err != nil {
+		return n, err
+	}
+	if v.Field(value).Type() != reflect.TypeFor[T]() {
+		return n, fmt.Errorf("bqopt: %s does not hold a %s", v.Type(), reflect.TypeFor[T]())
+	}
+	if o.IsPresent() {
+		v.Field(value).Set(reflect.ValueOf(o.Get()))
+		v.Field(valid).SetBool(true)
+	}
+	return n, nil
+}
+
+// nullFields returns the indexes of the value and Valid fields of the
+// bigquery Null type t.
+func nullFields(t reflect.Type) (value, valid int, err error) {
+	if t == nil || t.Kind() != reflect.Struct || t.NumField() != 2 {
+		return 0, 0, fmt.Errorf("bqopt: %v is not a bigquery Null type", t)
+	}
+	f, ok := t.FieldByName("Valid")
+	if !ok || f.Type.Kind() != reflect.Bool {
+		return 0, 0, fmt.Errorf("bqopt: %s is not a bigquery Null type", t)
+	}
+	return 1 - f.Index[0], f.Index[0], nil
+}
+
+// Row returns the exported fields of the struct v, or of the struct it
+// points to, keyed by their bigquery tag or else their name. Empty
+// Optionals and nil pointers are nil, which BigQuery stores as NULL, and
+// present Optionals are replaced by their value. Fields tagged "-" are
+// skipped.
+func Row(v any) map[string]any {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	t := rv.Type()
+	row := make(map[string]any, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("bigquery"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		row[name] = value(rv.Field(i))
+	}
+	return row
+}
+
+// value returns the value held by v, which may be a plain value, a pointer
+// or an Optional, or nil if there is none.
+func value(v reflect.Value) any {
+	if reflectopt.IsOptionalType(v.Type()) {
+		inner, ok := reflectopt.ValueOf(v)
+		if !ok {
+			return nil
+		}
+		return inner.Interface()
+	}
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil
+	}
+	return v.Interface()
+}
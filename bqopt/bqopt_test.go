@@ -0,0 +1,76 @@
+package bqopt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+// nullInt64 and nullTimestamp mirror bigquery.NullInt64 and
+// bigquery.NullTimestamp.
+type nullInt64 struct {
+	Int64 int64
+	Valid bool
+}
+
+type nullTimestamp struct {
+	Timestamp time.Time
+	Valid     bool
+}
+
+func TestFrom(t *testing.T) {
+	if got, err := From[int64](nullInt64{Int64: 42, Valid: true}); err != nil || got.OrZero() != 42 {
+		t.Errorf("Expected Optional[42], but got %v, %v", got, err)
+	}
+	if got, err := From[int64](nullInt64{Int64: 42}); err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v, %v", got, err)
+	}
+}
+
+func TestTo(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	if got, err := To[nullTimestamp](optional.Of(ts)); err != nil || !got.Valid || !got.Timestamp.Equal(ts) {
+		t.Errorf("Expected a valid timestamp, but got %+v, %v", got, err)
+	}
+	if got, err := To[nullInt64](optional.Empty[int64]()); err != nil || got.Valid {
+		t.Errorf("Expected an invalid value, but got %+v, %v", got, err)
+	}
+}
+
+func TestNotANullType(t *testing.T) {
+	if _, err := From[int](struct{ A, B int }{}); err == nil {
+		t.Error("Expected an error for a type without Valid, but got nil")
+	}
+	if _, err := From[int](42); err == nil {
+		t.Error("Expected an error for a non-struct value, but got nil")
+	}
+	if _, err := From[int](nil); err == nil {
+		t.Error("Expected an error for nil, but got nil")
+	}
+	if _, err := From[string](nullInt64{Int64: 42, Valid: true}); err == nil {
+		t.Error("Expected an error for a mismatched type, but got nil")
+	}
+	if _, err := To[int](optional.Of(42)); err == nil {
+		t.Error("Expected an error for a non-struct type, but got nil")
+	}
+	if _, err := To[nullInt64](optional.Of("42")); err == nil {
+		t.Error("Expected an error for a mismatched type, but got nil")
+	}
+}
+
+func TestRow(t *testing.T) {
+	type event struct {
+		ID      string
+		User    optional.Optional[string] `bigquery:"user_id"`
+		Amount  optional.Optional[float64]
+		Comment *string
+		Skipped string `bigquery:"-"`
+	}
+	got := Row(&event{ID: "e1", User: optional.Of("u1"), Skipped: "x"})
+	want := map[string]any{"ID": "e1", "user_id": "u1", "Amount": nil, "Comment": nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, but got %v", want, got)
+	}
+}
@@ -0,0 +1,105 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// MissingFieldError reports a required field that holds no value.
+type MissingFieldError struct {
+	Field string
+}
+
+func (e *MissingFieldError) Error() string {
+	return fmt.Sprintf("optional: required field %s is missing", e.Field)
+}
+
+// Builder assembles a struct of type T one field at a time. Fields tagged
+// `optional:"required"` must be set before Build succeeds.
+type Builder[T any] struct {
+	value T
+	set   map[string]bool
+	errs  []error
+}
+
+// NewBuilder returns an empty Builder for the struct type T.
+func NewBuilder[T any]() *Builder[T] {
+	if reflect.TypeFor[T]().Kind() != reflect.Struct {
+		panic("Optional.NewBuilder: type parameter must be a struct")
+	}
+	return &Builder[T]{set: map[string]bool{}}
+}
+
+// Set records value for the named field. The value must be assignable to the
+// field, or to the contents of a pointer or Optional field. An Optional value
+// is recorded only when it is present, and a nil value unsets the field.
+// Errors are reported by Build.
+func (b *Builder[T]) Set(field string, value any) *Builder[T] {
+	f, ok := b.field(field)
+	if !ok {
+		b.errs = append(b.errs, fmt.Errorf("optional: %s has no field %s", reflect.TypeFor[T](), field))
+		return b
+	}
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		f.Set(reflect.Zero(f.Type()))
+		delete(b.set, field)
+		return b
+	}
+	if isOptionalType(v.Type()) {
+		inner, present := optionalValue(v)
+		if !present {
+			return b
+		}
+		if v.Type() != f.Type() {
+			v = inner
+		}
+	}
+	if err := (&patcher{}).assign(f, v, field); err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	b.set[field] = true
+	return b
+}
+
+// Get returns the value recorded for the named field, if any.
+func (b *Builder[T]) Get(field string) Optional[any] {
+	f, ok := b.field(field)
+	if !ok || !b.set[field] {
+		return Empty[any]()
+	}
+	v, ok := fieldValue(f)
+	if !ok {
+		return Empty[any]()
+	}
+	return Of(v.Interface())
+}
+
+// Build returns the assembled struct, or an error joining every error
+// recorded by Set and a *MissingFieldError for each required field that
+// was not set.
+func (b *Builder[T]) Build() (T, error) {
+	errs := b.errs
+	t := reflect.TypeFor[T]()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.IsExported() && hasTagOption(f, "required") && !b.set[f.Name] {
+			errs = append(errs, &MissingFieldError{Field: f.Name})
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		var zero T
+		return zero, err
+	}
+	return b.value, nil
+}
+
+func (b *Builder[T]) field(name string) (reflect.Value, bool) {
+	f, ok := reflect.TypeFor[T]().FieldByName(name)
+	if !ok || !f.IsExported() || len(f.Index) != 1 {
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(&b.value).Elem().Field(f.Index[0]), true
+}
@@ -0,0 +1,73 @@
+package optional
+
+import (
+	"errors"
+	"testing"
+)
+
+type builderConfig struct {
+	Host    string `optional:"required"`
+	Port    int    `optional:"required"`
+	Timeout *int
+	Label   Optional[string]
+}
+
+func TestBuilderBuild(t *testing.T) {
+	config, err := NewBuilder[builderConfig]().
+		Set("Host", "localhost").
+		Set("Port", Of(8080)).
+		Set("Timeout", 30).
+		Set("Label", "primary").
+		Build()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if config.Host != "localhost" || config.Port != 8080 {
+		t.Errorf("Expected localhost:8080, but got %s:%d", config.Host, config.Port)
+	}
+	if config.Timeout == nil || *config.Timeout != 30 {
+		t.Errorf("Expected timeout 30, but got %v", config.Timeout)
+	}
+	if config.Label.OrElse("") != "primary" {
+		t.Errorf("Expected label primary, but got %v", config.Label)
+	}
+}
+
+func TestBuilderMissingFields(t *testing.T) {
+	_, err := NewBuilder[builderConfig]().
+		Set("Port", Empty[int]()).
+		Build()
+	var missing *MissingFieldError
+	if !errors.As(err, &missing) {
+		t.Fatalf("Expected MissingFieldError, but got %v", err)
+	}
+	want := "optional: required field Host is missing\noptional: required field Port is missing"
+	if err.Error() != want {
+		t.Errorf("Expected error %q, but got %q", want, err.Error())
+	}
+}
+
+func TestBuilderSetErrors(t *testing.T) {
+	_, err := NewBuilder[builderConfig]().
+		Set("Host", "h").
+		Set("Port", "not a number").
+		Set("Unknown", 1).
+		Build()
+	if err == nil {
+		t.Errorf("Expected errors for invalid Set calls, but got none")
+	}
+}
+
+func TestBuilderGet(t *testing.T) {
+	b := NewBuilder[builderConfig]().Set("Host", "h")
+	if got := b.Get("Host"); got.OrElse(nil) != "h" {
+		t.Errorf("Expected Host to be h, but got %v", got)
+	}
+	if got := b.Get("Port"); got.IsPresent() {
+		t.Errorf("Expected unset field to be empty, but got %v", got)
+	}
+	b.Set("Host", nil)
+	if got := b.Get("Host"); got.IsPresent() {
+		t.Errorf("Expected nil to unset the field, but got %v", got)
+	}
+}
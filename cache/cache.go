@@ -0,0 +1,133 @@
+// Package cache provides an LRU cache of Optionals that remembers missing
+// keys as well as present ones (negative caching), so repeated lookups of
+// keys known not to exist do not reach the backing store.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+// Config configures an LRU.
+type Config struct {
+	// Capacity is the maximum number of entries; the least recently used
+	// entry is evicted to make room. It must be positive.
+	Capacity int
+	// TTL is how long a present value stays cached. Zero means until it is
+	// evicted.
+	TTL time.Duration
+	// NegativeTTL is how long an empty Optional stays cached. Zero disables
+	// negative caching.
+	NegativeTTL time.Duration
+}
+
+// LRU is a least-recently-used cache of Optionals. It is safe for
+// concurrent use.
+type LRU[K comparable, V any] struct {
+	config Config
+	now    func() time.Time
+
+	mu    sync.Mutex
+	order *list.List
+	items map[K]*list.Element
+}
+
+type entry[K comparable, V any] struct {
+	key     K
+	value   optional.Optional[V]
+	expires time.Time
+}
+
+// New returns an empty LRU. It panics if config.Capacity is not positive.
+func New[K comparable, V any](config Config) *LRU[K, V] {
+	if config.Capacity <= 0 {
+		panic("cache.New: capacity must be positive")
+	}
+	return &LRU[K, V]{config: config, now: time.Now, order: list.New(), items: map[K]*list.Element{}}
+}
+
+// Get returns the cached Optional for key and true, or false if key is not
+// cached or its entry expired. A cached empty Optional means the key is
+// known to be missing.
+func (c *LRU[K, V]) Get(key K) (optional.Optional[V], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return optional.Empty[V](), false
+	}
+	e := el.Value.(*entry[K, V])
+	if !e.expires.IsZero() && !c.now().Before(e.expires) {
+		c.remove(el)
+		return optional.Empty[V](), false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// Add caches value for key, replacing any previous entry. Empty values are
+// ignored when negative caching is disabled.
+func (c *LRU[K, V]) Add(key K, value optional.Optional[V]) {
+	ttl := c.config.TTL
+	if value.IsEmpty() {
+		if c.config.NegativeTTL <= 0 {
+			c.Remove(key)
+			return
+		}
+		ttl = c.config.NegativeTTL
+	}
+	e := &entry[K, V]{key: key, value: value}
+	if ttl > 0 {
+		e.expires = c.now().Add(ttl)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(e)
+	if c.order.Len() > c.config.Capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+// GetOrLoad returns the cached Optional for key, or calls load and caches
+// its result. Errors from load are returned and not cached.
+func (c *LRU[K, V]) GetOrLoad(key K, load func(K) (optional.Optional[V], error)) (optional.Optional[V], error) {
+	if v, ok := c.Get(key); ok {
+		return v, nil
+	}
+	v, err := load(key)
+	if err != nil {
+		return optional.Empty[V](), err
+	}
+	c.Add(key, v)
+	return v, nil
+}
+
+// Remove removes key from the cache.
+func (c *LRU[K, V]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+}
+
+// Len returns the number of cached entries, including expired ones not yet
+// removed.
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRU[K, V]) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*entry[K, V]).key)
+}
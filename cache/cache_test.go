@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+func TestLRUEviction(t *testing.T) {
+	c := New[string, int](Config{Capacity: 2})
+	c.Add("a", optional.Of(1))
+	c.Add("b", optional.Of(2))
+	c.Get("a")
+	c.Add("c", optional.Of(3))
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("Expected b to be evicted")
+	}
+	if v, ok := c.Get("a"); !ok || v.OrZero() != 1 {
+		t.Errorf("Expected a to be cached, but got %v, %v", v, ok)
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 entries, but got %d", c.Len())
+	}
+	c.Remove("a")
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Expected a to be removed")
+	}
+}
+
+func TestLRUNegativeCaching(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	c := New[string, int](Config{Capacity: 10, TTL: time.Hour, NegativeTTL: time.Minute})
+	c.now = func() time.Time { return now }
+
+	c.Add("present", optional.Of(1))
+	c.Add("missing", optional.Empty[int]())
+	if v, ok := c.Get("missing"); !ok || v.IsPresent() {
+		t.Errorf("Expected a cached empty Optional, but got %v, %v", v, ok)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get("missing"); ok {
+		t.Errorf("Expected the negative entry to expire")
+	}
+	if v, ok := c.Get("present"); !ok || v.OrZero() != 1 {
+		t.Errorf("Expected the present entry to stay cached, but got %v, %v", v, ok)
+	}
+
+	now = now.Add(time.Hour)
+	if _, ok := c.Get("present"); ok {
+		t.Errorf("Expected the present entry to expire")
+	}
+}
+
+func TestLRUWithoutNegativeCaching(t *testing.T) {
+	c := New[string, int](Config{Capacity: 10})
+	c.Add("k", optional.Of(1))
+	c.Add("k", optional.Empty[int]())
+	if _, ok := c.Get("k"); ok {
+		t.Errorf("Expected an empty Optional not to be cached")
+	}
+}
+
+func TestLRUGetOrLoad(t *testing.T) {
+	c := New[string, int](Config{Capacity: 10, NegativeTTL: time.Minute})
+	loads := 0
+	load := func(string) (optional.Optional[int], error) {
+		loads++
+		return optional.Empty[int](), nil
+	}
+	for range 3 {
+		if v, err := c.GetOrLoad("missing", load); err != nil || v.IsPresent() {
+			t.Errorf("Expected an empty Optional, but got %v, %v", v, err)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("Expected 1 load, but got %d", loads)
+	}
+
+	errDown := errors.New("store down")
+	failing := func(string) (optional.Optional[int], error) { return optional.Empty[int](), errDown }
+	if _, err := c.GetOrLoad("other", failing); err != errDown {
+		t.Errorf("Expected %v, but got %v", errDown, err)
+	}
+	if _, ok := c.Get("other"); ok {
+		t.Errorf("Expected errors not to be cached")
+	}
+}
+
+func TestNewPanicsWithoutCapacity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for a zero capacity")
+		}
+	}()
+	New[string, int](Config{})
+}
@@ -0,0 +1,72 @@
+// Package check provides reusable validators for use with Optional.Validate
+// and Optional.ValidatePresent:
+//
+//	err := name.Validate(check.MinLen[string](2), check.MaxLen[string](64))
+//	err = role.ValidatePresent(check.OneOf("admin", "user"))
+//
+// Validate lets empty values pass, while ValidatePresent treats them as
+// missing, which marks the value as required.
+package check
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// ErrInvalid is wrapped by every error returned by the validators.
+var ErrInvalid = errors.New("check: invalid value")
+
+// MinLen returns a validator rejecting strings shorter than n runes.
+func MinLen[T ~string](n int) func(T) error {
+	return func(s T) error {
+		if l := utf8.RuneCountInString(string(s)); l < n {
+			return fmt.Errorf("%w: length %d is less than %d", ErrInvalid, l, n)
+		}
+		return nil
+	}
+}
+
+// MaxLen returns a validator rejecting strings longer than n runes.
+func MaxLen[T ~string](n int) func(T) error {
+	return func(s T) error {
+		if l := utf8.RuneCountInString(string(s)); l > n {
+			return fmt.Errorf("%w: length %d is greater than %d", ErrInvalid, l, n)
+		}
+		return nil
+	}
+}
+
+// Range returns a validator rejecting values outside [lo, hi].
+func Range[T cmp.Ordered](lo, hi T) func(T) error {
+	return func(v T) error {
+		if v < lo || v > hi {
+			return fmt.Errorf("%w: %v is not between %v and %v", ErrInvalid, v, lo, hi)
+		}
+		return nil
+	}
+}
+
+// MatchRegex returns a validator rejecting strings that do not match re.
+func MatchRegex[T ~string](re *regexp.Regexp) func(T) error {
+	return func(s T) error {
+		if !re.MatchString(string(s)) {
+			return fmt.Errorf("%w: %q does not match %s", ErrInvalid, s, re)
+		}
+		return nil
+	}
+}
+
+// OneOf returns a validator rejecting values other than the given ones.
+func OneOf[T comparable](values ...T) func(T) error {
+	return func(v T) error {
+		for _, allowed := range values {
+			if v == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: %v is not one of %v", ErrInvalid, v, values)
+	}
+}
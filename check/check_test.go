@@ -0,0 +1,71 @@
+package check
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+func TestLen(t *testing.T) {
+	if err := MinLen[string](2)("héllo"); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	if err := MinLen[string](2)("é"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid, but got %v", err)
+	}
+	if err := MaxLen[string](5)("héllo"); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	if err := MaxLen[string](4)("héllo"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid, but got %v", err)
+	}
+}
+
+func TestRange(t *testing.T) {
+	r := Range(1, 10)
+	for _, v := range []int{1, 5, 10} {
+		if err := r(v); err != nil {
+			t.Errorf("Expected %d to pass, but got %v", v, err)
+		}
+	}
+	for _, v := range []int{0, 11} {
+		if err := r(v); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected %d to fail, but got %v", v, err)
+		}
+	}
+}
+
+func TestMatchRegex(t *testing.T) {
+	m := MatchRegex[string](regexp.MustCompile(`^[a-z]+$`))
+	if err := m("abc"); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	if err := m("ABC"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid, but got %v", err)
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	o := OneOf("admin", "user")
+	if err := o("user"); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	if err := o("root"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid, but got %v", err)
+	}
+}
+
+func TestWithValidate(t *testing.T) {
+	if err := optional.Empty[string]().Validate(MinLen[string](2)); err != nil {
+		t.Errorf("Expected an empty value to pass, but got %v", err)
+	}
+	if err := optional.Empty[string]().ValidatePresent(MinLen[string](2)); !errors.Is(err, optional.ErrNotPresent) {
+		t.Errorf("Expected ErrNotPresent, but got %v", err)
+	}
+	err := optional.Of("x").Validate(MinLen[string](2), OneOf("ab", "cd"))
+	if !errors.Is(err, ErrInvalid) || len(err.(interface{ Unwrap() []error }).Unwrap()) != 2 {
+		t.Errorf("Expected two failures, but got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Package chopt maps row structs with Optional fields to the native API of
+// github.com/ClickHouse/clickhouse-go, where Nullable(T) columns are read
+// into **T destinations and appended from *T values, nil meaning NULL.
+//
+// Columns correspond to the exported fields of the struct in order, skipping
+// fields tagged `ch:"-"`, so the struct lists them as the query does:
+//
+//	type Visit struct {
+//		URL      string
+//		Referrer optional.Optional[string]        // Nullable(String)
+//		Duration optional.Optional[time.Duration] // Nullable(Int64)
+//	}
+//
+//	for rows.Next() {
+//		var v Visit
+//		if err := chopt.ScanRow(rows, &v); err != nil {
+//			return err
+//		}
+//	}
+//
+//	err := batch.Append(chopt.AppendValues(v)...)
+//
+// The package relies only on the Scan method of driver.Rows and driver.Row,
+// so it does not import clickhouse-go itself. Through database/sql, the
+// clickhouse-go standard driver needs no adapter, as Optional implements
+// sql.Scanner and driver.Valuer.
+package chopt
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/hermann-craft/optional/reflectopt"
+)
+
+// RowScanner is implemented by the driver.Rows and driver.Row types of
+// clickhouse-go.
+type RowScanner interface {
+	Scan(dest ...any) error
+}
+
+// ScanRow scans the current row of rows into the struct dst points to. A
+// NULL leaves an Optional field empty.
+func ScanRow(rows RowScanner, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("chopt: ScanRow needs a pointer to a struct, not %T", dst)
+	}
+	names, fields := columns(v.Elem())
+	dest := make([]any, len(fields))
+	for i, f := range fields {
+		if elem := reflectopt.ElemType(f.Type()); elem != nil {
+			dest[i] = reflect.New(reflect.PointerTo(elem)).Interface()
+			continue
+		}
+		dest[i] = f.Addr().Interface()
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return err
+	}
+	for i, f := range fields {
+		if !reflectopt.IsOptionalType(f.Type()) {
+			continue
+		}
+		var src any
+		if p := reflect.ValueOf(dest[i]).Elem(); !p.IsNil() {
+			src = p.Elem().Interface()
+		}
+		if err := f.Addr().Interface().(sql.Scanner).Scan(src); err != nil {
+			return fmt.Errorf("chopt: field %s: %w", names[i], err)
+		}
+	}
+	return nil
+}
+
+// AppendValues returns the column values of the struct v, or of the struct
+// it points to, for batch.Append. Optional fields become a pointer to their
+// value, or a nil pointer of the same type when empty.
+func AppendValues(v any) []any {
+	_, fields := columns(reflect.Indirect(reflect.ValueOf(v)))
+	values := make([]any, len(fields))
+	for i, f := range fields {
+		elem := reflectopt.ElemType(f.Type())
+		if elem == nil {
+			values[i] = f.Interface()
+			continue
+		}
+		p := reflect.Zero(reflect.PointerTo(elem))
+		if inner, ok := reflectopt.ValueOf(f); ok {
+			p = reflect.New(elem)
+			p.Elem().Set(inner)
+		}
+		values[i] = p.Interface()
+	}
+	return values
+}
+
+// columns returns the names and values of the fields of the struct v that
+// map to columns.
+func columns(v reflect.Value) (names []string, fields []reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() && f.Tag.Get("ch") != "-" {
+			names = append(names, f.Name)
+			fields = append(fields, v.Field(i))
+		}
+	}
+	return names, fields
+}
@@ -0,0 +1,88 @@
+package chopt
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+type visit struct {
+	URL      string
+	Referrer optional.Optional[string]
+	Duration optional.Optional[time.Duration]
+	Ignored  string `ch:"-"`
+}
+
+// unscannable fails to scan any value.
+type unscannable string
+
+func (u *unscannable) Scan(src any) error {
+	return errors.New("unscannable")
+}
+
+// row mimics driver.Row of clickhouse-go, which scans Nullable(T) columns
+// into **T destinations.
+type row []any
+
+func (r row) Scan(dest ...any) error {
+	if len(dest) != len(r) {
+		return fmt.Errorf("expected %d destinations, got %d", len(r), len(dest))
+	}
+	for i, v := range r {
+		d := reflect.ValueOf(dest[i]).Elem()
+		switch {
+		case v == nil:
+			d.Set(reflect.Zero(d.Type()))
+		case d.Kind() == reflect.Pointer:
+			p := reflect.New(d.Type().Elem())
+			p.Elem().Set(reflect.ValueOf(v))
+			d.Set(p)
+		default:
+			d.Set(reflect.ValueOf(v))
+		}
+	}
+	return nil
+}
+
+func TestScanRow(t *testing.T) {
+	var v visit
+	if err := ScanRow(row{"/home", "https://example.com", nil}, &v); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if v.URL != "/home" || v.Referrer.OrZero() != "https://example.com" || v.Duration.IsPresent() {
+		t.Errorf("Expected /home with a referrer and no duration, but got %+v", v)
+	}
+	if err := ScanRow(row{"/home"}, &v); err == nil {
+		t.Errorf("Expected an error for a column count mismatch, but got nil")
+	}
+	if err := ScanRow(row{}, v); err == nil {
+		t.Errorf("Expected an error for a non-pointer, but got nil")
+	}
+	var bad struct {
+		Tag optional.Optional[unscannable]
+	}
+	if err := ScanRow(row{unscannable("x")}, &bad); err == nil || !strings.Contains(err.Error(), "field Tag") {
+		t.Errorf("Expected an error naming the field Tag, but got %v", err)
+	}
+}
+
+func TestAppendValues(t *testing.T) {
+	values := AppendValues(visit{URL: "/home", Duration: optional.Of(time.Second), Ignored: "x"})
+	if len(values) != 3 {
+		t.Fatalf("Expected 3 values, but got %v", values)
+	}
+	if values[0] != "/home" {
+		t.Errorf("Expected /home, but got %v", values[0])
+	}
+	if p, ok := values[1].(*string); !ok || p != nil {
+		t.Errorf("Expected a nil *string, but got %#v", values[1])
+	}
+	if p, ok := values[2].(*time.Duration); !ok || p == nil || *p != time.Second {
+		t.Errorf("Expected a pointer to 1s, but got %#v", values[2])
+	}
+}
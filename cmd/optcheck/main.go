@@ -0,0 +1,15 @@
+// Command optcheck reports panic-prone uses of the optional package.
+//
+// It can be run directly or as a vet tool:
+//
+//	go vet -vettool=$(which optcheck) ./...
+package main
+
+import (
+	"github.com/hermann-craft/optional/optcheck"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	multichecker.Main(optcheck.Analyzer, optcheck.NilOfAnalyzer)
+}
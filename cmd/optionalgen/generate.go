@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	optionalPath = "github.com/hermann-craft/optional"
+	marker       = "//optionalgen"
+)
+
+type structInfo struct {
+	Name   string
+	Fields []fieldInfo
+}
+
+type fieldInfo struct {
+	Name string
+	Type string
+}
+
+type importInfo struct {
+	Alias string // empty when the name matches the last path element
+	Path  string
+}
+
+// generate parses the Go package in dir and returns its name together with
+// the generated source for its annotated structs. The source is nil when no
+// struct is annotated.
+func generate(dir string) (string, []byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return "", nil, err
+		}
+		if ast.IsGenerated(f) {
+			continue
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return "", nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	g := &generator{fset: fset, imports: map[string]string{}}
+	for _, f := range files {
+		if err := g.file(f); err != nil {
+			return "", nil, err
+		}
+	}
+	pkg := files[0].Name.Name
+	if len(g.structs) == 0 {
+		return pkg, nil, nil
+	}
+	src, err := g.render(pkg)
+	return pkg, src, err
+}
+
+type generator struct {
+	fset     *token.FileSet
+	structs  []structInfo
+	optional string
+	imports  map[string]string // local name -> path
+}
+
+func (g *generator) file(f *ast.File) error {
+	fileImports := map[string]string{}
+	for _, spec := range f.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		fileImports[name] = path
+	}
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			doc := ts.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			if !annotated(doc) {
+				continue
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok || ts.TypeParams != nil {
+				return fmt.Errorf("%s: %s must be a non-generic struct", g.fset.Position(ts.Pos()), ts.Name.Name)
+			}
+			info, err := g.structInfo(ts.Name.Name, st, fileImports)
+			if err != nil {
+				return err
+			}
+			g.structs = append(g.structs, info)
+		}
+	}
+	return nil
+}
+
+func (g *generator) structInfo(name string, st *ast.StructType, fileImports map[string]string) (structInfo, error) {
+	info := structInfo{Name: name}
+	for _, field := range st.Fields.List {
+		index, ok := field.Type.(*ast.IndexExpr)
+		if !ok {
+			continue
+		}
+		sel, ok := index.X.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Optional" {
+			continue
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok || fileImports[pkg.Name] != optionalPath {
+			continue
+		}
+		if err := g.use(pkg.Name, optionalPath); err != nil {
+			return info, err
+		}
+		if g.optional == "" {
+			g.optional = pkg.Name
+		}
+		var err error
+		ast.Inspect(index.Index, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok {
+					path, found := fileImports[id.Name]
+					if !found {
+						err = fmt.Errorf("%s: cannot resolve package %s", g.fset.Position(id.Pos()), id.Name)
+						return false
+					}
+					err = g.use(id.Name, path)
+				}
+			}
+			return err == nil
+		})
+		if err != nil {
+			return info, err
+		}
+		var typ bytes.Buffer
+		if err := printer.Fprint(&typ, g.fset, index.Index); err != nil {
+			return info, err
+		}
+		for _, n := range field.Names {
+			info.Fields = append(info.Fields, fieldInfo{Name: n.Name, Type: typ.String()})
+		}
+	}
+	if len(info.Fields) == 0 {
+		return info, fmt.Errorf("%s has no Optional fields", name)
+	}
+	if len(info.Fields) > 64 {
+		return info, fmt.Errorf("%s has %d Optional fields; at most 64 are supported", name, len(info.Fields))
+	}
+	return info, nil
+}
+
+// use records that the generated file needs path imported as name.
+func (g *generator) use(name, path string) error {
+	if prev, ok := g.imports[name]; ok && prev != path {
+		return fmt.Errorf("conflicting imports %q and %q both named %s", prev, path, name)
+	}
+	g.imports[name] = path
+	return nil
+}
+
+func (g *generator) render(pkg string) ([]byte, error) {
+	var imports []importInfo
+	for name, path := range g.imports {
+		info := importInfo{Path: path}
+		if name != path[strings.LastIndex(path, "/")+1:] {
+			info.Alias = name
+		}
+		imports = append(imports, info)
+	}
+	sort.Slice(imports, func(i, j int) bool { return imports[i].Path < imports[j].Path })
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string]any{
+		"Package":  pkg,
+		"Imports":  imports,
+		"Optional": g.optional,
+		"Structs":  g.structs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+func annotated(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.TrimSpace(c.Text) == marker {
+			return true
+		}
+	}
+	return false
+}
+
+var tmpl = template.Must(template.New("optionalgen").Parse(`// Code generated by optionalgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{range .Imports}}	{{with .Alias}}{{.}} {{end}}"{{.Path}}"
+{{end}})
+{{range .Structs}}{{$s := .}}
+// {{.Name}}Fields is a bitset of the Optional fields of {{.Name}}.
+type {{.Name}}Fields uint64
+
+// Bits of {{.Name}}Fields, one per Optional field.
+const (
+{{range $i, $f := .Fields}}	{{$s.Name}}Field{{$f.Name}}{{if eq $i 0}} {{$s.Name}}Fields = 1 << iota{{end}}
+{{end}})
+
+// Has reports whether every field in mask is set in f.
+func (f {{.Name}}Fields) Has(mask {{.Name}}Fields) bool {
+	return f&mask == mask
+}
+
+// PresentFields returns the set of Optional fields of s holding a value.
+func (s *{{.Name}}) PresentFields() {{.Name}}Fields {
+	var f {{.Name}}Fields
+	if s == nil {
+		return f
+	}
+{{range .Fields}}	if s.{{.Name}}.IsPresent() {
+		f |= {{$s.Name}}Field{{.Name}}
+	}
+{{end}}	return f
+}
+{{range .Fields}}
+// Get{{.Name}} returns the value of {{.Name}} and whether it is present.
+func (s *{{$s.Name}}) Get{{.Name}}() ({{.Type}}, bool) {
+	if s == nil || s.{{.Name}}.IsEmpty() {
+		var zero {{.Type}}
+		return zero, false
+	}
+	return s.{{.Name}}.Get(), true
+}
+
+// Set{{.Name}} sets {{.Name}} to v.
+func (s *{{$s.Name}}) Set{{.Name}}(v {{.Type}}) {
+	s.{{.Name}} = {{$.Optional}}.Of(v)
+}
+
+// Clear{{.Name}} empties {{.Name}}.
+func (s *{{$s.Name}}) Clear{{.Name}}() {
+	s.{{.Name}} = {{$.Optional}}.Empty[{{.Type}}]()
+}
+
+// With{{.Name}} sets {{.Name}} to v and returns s.
+func (s *{{$s.Name}}) With{{.Name}}(v {{.Type}}) *{{$s.Name}} {
+	s.Set{{.Name}}(v)
+	return s
+}
+{{end}}{{end}}`))
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, src string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "user.go", `package model
+
+import (
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+//optionalgen
+type User struct {
+	ID        int
+	Name      optional.Optional[string]
+	CreatedAt optional.Optional[time.Time]
+}
+
+type Ignored struct {
+	Name optional.Optional[string]
+}
+`)
+	pkg, src, err := generate(dir)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if pkg != "model" {
+		t.Errorf("Expected package model, but got %s", pkg)
+	}
+	out := string(src)
+	for _, want := range []string{
+		"// Code generated by optionalgen. DO NOT EDIT.",
+		`"time"`,
+		"func (s *User) GetName() (string, bool)",
+		"func (s *User) SetName(v string)",
+		"func (s *User) ClearName()",
+		"func (s *User) WithName(v string) *User",
+		"func (s *User) GetCreatedAt() (time.Time, bool)",
+		"s.CreatedAt = optional.Empty[time.Time]()",
+		"UserFieldName UserFields = 1 << iota",
+		"func (s *User) PresentFields() UserFields",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected generated code to contain %q, but it did not:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Ignored") || strings.Contains(out, "GetID") {
+		t.Errorf("Expected only annotated Optional fields to be generated, but got:\n%s", out)
+	}
+}
+
+func TestGenerateAlias(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.go", `package a
+
+import opt "github.com/hermann-craft/optional"
+
+//optionalgen
+type Config struct {
+	Port, Timeout opt.Optional[int]
+}
+`)
+	_, src, err := generate(dir)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	out := string(src)
+	if !strings.Contains(out, `opt "github.com/hermann-craft/optional"`) || !strings.Contains(out, "s.Port = opt.Of(v)") {
+		t.Errorf("Expected generated code to use the opt alias, but got:\n%s", out)
+	}
+	if !strings.Contains(out, "func (s *Config) GetTimeout() (int, bool)") {
+		t.Errorf("Expected accessors for every field name, but got:\n%s", out)
+	}
+}
+
+func TestGenerateNoAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.go", "package a\n\ntype A struct{}\n")
+	_, src, err := generate(dir)
+	if err != nil || src != nil {
+		t.Errorf("Expected no output and no error, but got %q, %v", src, err)
+	}
+}
+
+func TestGenerateNoOptionalFields(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.go", "package a\n\n//optionalgen\ntype A struct{ X int }\n")
+	if _, _, err := generate(dir); err == nil {
+		t.Errorf("Expected error for annotated struct without Optional fields, but got none")
+	}
+}
@@ -0,0 +1,70 @@
+// Command optionalgen generates accessors for the Optional fields of
+// annotated structs.
+//
+// A struct is annotated by placing an //optionalgen comment directly above
+// its declaration:
+//
+//	//go:generate optionalgen
+//
+//	//optionalgen
+//	type User struct {
+//		Name  optional.Optional[string]
+//		Email optional.Optional[string]
+//	}
+//
+// For every Optional field X of type T, optionalgen generates
+//
+//	func (s *User) GetX() (T, bool)
+//	func (s *User) SetX(v T)
+//	func (s *User) ClearX()
+//	func (s *User) WithX(v T) *User
+//
+// together with a UserFields bitset and a PresentFields method reporting
+// which fields currently hold a value.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("optionalgen: ")
+
+	output := flag.String("output", "", "output file name; default <package>_optionalgen.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: optionalgen [-output file] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		dir = flag.Arg(0)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	pkg, src, err := generate(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if src == nil {
+		log.Printf("no //optionalgen structs found in %s", dir)
+		return
+	}
+	name := *output
+	if name == "" {
+		name = pkg + "_optionalgen.go"
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
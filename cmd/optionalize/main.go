@@ -0,0 +1,95 @@
+// Command optionalize rewrites struct fields using a pointer for
+// optionality into Optional fields across a module, to help adopt the
+// optional package in an existing codebase:
+//
+//	optionalize -w -field User.Nickname -field model.Address.Line2 ./...
+//
+// Each field is named Type.Field, or importpath.Type.Field when the type
+// name is ambiguous, and must have a pointer type *T, which becomes
+// Optional[T]. Uses of the field that have a direct Optional counterpart
+// are rewritten as well:
+//
+//	u.Nickname == nil      u.Nickname.IsEmpty()
+//	u.Nickname != nil      u.Nickname.IsPresent()
+//	*u.Nickname            u.Nickname.Get()
+//	u.Nickname = nil       u.Nickname = optional.Empty[string]()
+//	u.Nickname = &name     u.Nickname = optional.OfNullable(&name)
+//	User{Address: &a{}}    User{Address: optional.Of(a{})}
+//
+// Other uses, such as passing the pointer to a function or assigning
+// through it, are reported with their position and left unchanged for
+// manual migration. Without -w, optionalize prints the rewritten files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// fieldList collects the repeated -field flags.
+type fieldList []string
+
+func (l *fieldList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *fieldList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("optionalize: ")
+
+	var fields fieldList
+	flag.Var(&fields, "field", "field to convert, as Type.Field or importpath.Type.Field; may be repeated")
+	write := flag.Bool("w", false, "write the rewritten files instead of printing them")
+	list := flag.Bool("l", false, "list the files that would change")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: optionalize [-w] [-l] -field Type.Field... [packages]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if len(fields) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	res, err := rewrite(".", patterns, fields)
+	if err != nil {
+		log.Fatal(err)
+	}
+	names := make([]string, 0, len(res.Files))
+	for name := range res.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if *list {
+			fmt.Println(name)
+		}
+		if *write {
+			if err := os.WriteFile(name, res.Files[name], 0o644); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if !*list && !*write {
+			fmt.Printf("// %s\n%s", name, res.Files[name])
+		}
+	}
+	for _, s := range res.Skipped {
+		fmt.Fprintln(os.Stderr, s)
+	}
+	if len(res.Skipped) > 0 {
+		os.Exit(1)
+	}
+}
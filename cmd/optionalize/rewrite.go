@@ -0,0 +1,429 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+)
+
+const optionalPath = "github.com/hermann-craft/optional"
+
+// result holds the outcome of a rewrite.
+type result struct {
+	// Files maps the names of the changed files to their new source.
+	Files map[string][]byte
+	// Skipped lists the uses of the selected fields that could not be
+	// rewritten, as "file:line:col: description".
+	Skipped []string
+}
+
+// target is a selected field, identified by the position of its
+// declaration so that it matches across the test variants of a package.
+type target struct {
+	name string // Type.Field
+	elem types.Type
+}
+
+// rewrite loads the packages matching patterns in dir and converts the
+// selected fields, given as Type.Field or importpath.Type.Field, from *T to
+// Optional[T].
+func rewrite(dir string, patterns, fields []string) (*result, error) {
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:   dir,
+		Tests: true,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	var errs []string
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		for _, e := range p.Errors {
+			errs = append(errs, e.Error())
+		}
+	})
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("loading packages:\n%s", strings.Join(errs, "\n"))
+	}
+
+	targets, err := findTargets(pkgs, fields)
+	if err != nil {
+		return nil, err
+	}
+	res := &result{Files: map[string][]byte{}}
+	seen := map[string]bool{}
+	for _, p := range pkgs {
+		for _, f := range p.Syntax {
+			name := p.Fset.File(f.Pos()).Name()
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			r := &rewriter{pkg: p, file: f, targets: targets, handled: map[ast.Node]bool{}, writes: map[ast.Node]bool{}}
+			changed := r.rewrite()
+			res.Skipped = append(res.Skipped, r.skipped...)
+			if !changed {
+				continue
+			}
+			var buf bytes.Buffer
+			if err := format.Node(&buf, p.Fset, f); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			res.Files[name] = buf.Bytes()
+		}
+	}
+	sort.Strings(res.Skipped)
+	return res, nil
+}
+
+// findTargets resolves the field selectors to the pointer fields they name.
+func findTargets(pkgs []*packages.Package, fields []string) (map[token.Position]target, error) {
+	targets := map[token.Position]target{}
+	for _, spec := range fields {
+		parts := strings.Split(spec, ".")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("invalid field %q: want Type.Field or importpath.Type.Field", spec)
+		}
+		path := strings.Join(parts[:len(parts)-2], ".")
+		typeName, fieldName := parts[len(parts)-2], parts[len(parts)-1]
+
+		found := map[token.Position]*types.Var{}
+		packages.Visit(pkgs, nil, func(p *packages.Package) {
+			if path != "" && p.PkgPath != path {
+				return
+			}
+			obj, ok := p.Types.Scope().Lookup(typeName).(*types.TypeName)
+			if !ok {
+				return
+			}
+			st, ok := obj.Type().Underlying().(*types.Struct)
+			if !ok {
+				return
+			}
+			for i := 0; i < st.NumFields(); i++ {
+				if f := st.Field(i); f.Name() == fieldName && !f.Embedded() {
+					found[p.Fset.Position(f.Pos())] = f
+				}
+			}
+		})
+		if len(found) == 0 {
+			return nil, fmt.Errorf("field %s not found", spec)
+		}
+		if len(found) > 1 {
+			return nil, fmt.Errorf("field %s is ambiguous; qualify it with the import path", spec)
+		}
+		for pos, f := range found {
+			ptr, ok := f.Type().(*types.Pointer)
+			if !ok {
+				return nil, fmt.Errorf("field %s is not a pointer", spec)
+			}
+			targets[pos] = target{name: typeName + "." + fieldName, elem: ptr.Elem()}
+		}
+	}
+	return targets, nil
+}
+
+// rewriter rewrites a single file.
+type rewriter struct {
+	pkg     *packages.Package
+	file    *ast.File
+	targets map[token.Position]target
+	changed bool
+	skipped []string
+	// handled holds the field references already accounted for.
+	handled map[ast.Node]bool
+	// writes holds the expressions assigned to or addressed.
+	writes map[ast.Node]bool
+}
+
+// rewrite rewrites the file and reports whether it changed.
+func (r *rewriter) rewrite() bool {
+	astutil.Apply(r.file, r.pre, nil)
+	return r.changed
+}
+
+func (r *rewriter) pre(c *astutil.Cursor) bool {
+	switch n := c.Node().(type) {
+	case *ast.Field:
+		r.field(n)
+	case *ast.AssignStmt:
+		for _, lhs := range n.Lhs {
+			r.markWrite(lhs)
+		}
+		if n.Tok != token.ASSIGN || len(n.Lhs) != len(n.Rhs) {
+			return true
+		}
+		for i, lhs := range n.Lhs {
+			if t, ok := r.ref(lhs); ok {
+				r.handled[lhs] = true
+				n.Rhs[i] = r.value(t, n.Rhs[i])
+			}
+		}
+	case *ast.IncDecStmt:
+		r.markWrite(n.X)
+	case *ast.RangeStmt:
+		r.markWrite(n.Key)
+		r.markWrite(n.Value)
+	case *ast.UnaryExpr:
+		if n.Op == token.AND {
+			r.markWrite(n.X)
+		}
+	case *ast.CompositeLit:
+		r.compositeLit(n)
+	case *ast.BinaryExpr:
+		if n.Op != token.EQL && n.Op != token.NEQ {
+			return true
+		}
+		x, y := n.X, n.Y
+		if r.isNil(x) {
+			x, y = y, x
+		}
+		if _, ok := r.ref(x); ok && r.isNil(y) {
+			method := "IsEmpty"
+			if n.Op == token.NEQ {
+				method = "IsPresent"
+			}
+			r.replace(c, call(x, method))
+			return false
+		}
+	case *ast.StarExpr:
+		if _, ok := r.ref(n.X); ok {
+			if r.writes[n] {
+				r.skip(n, "assignment through %s")
+				return false
+			}
+			r.replace(c, call(n.X, "Get"))
+			return false
+		}
+	case *ast.SelectorExpr:
+		if _, ok := r.ref(n.X); ok {
+			if sel, ok := r.pkg.TypesInfo.Selections[n]; ok && sel.Kind() == types.FieldVal && !r.writes[n] {
+				n.X = call(n.X, "Get")
+				r.changed = true
+				return false
+			}
+			r.skip(n.X, "use of %s")
+			return false
+		}
+		if _, ok := r.ref(n); ok && !r.handled[n] {
+			r.skip(n, "use of %s")
+		}
+	}
+	return true
+}
+
+// field rewrites the declaration of selected fields.
+func (r *rewriter) field(f *ast.Field) {
+	star, ok := f.Type.(*ast.StarExpr)
+	if !ok || len(f.Names) == 0 {
+		return
+	}
+	selected := 0
+	for _, name := range f.Names {
+		if _, ok := r.targets[r.pkg.Fset.Position(name.Pos())]; ok {
+			selected++
+		}
+	}
+	switch selected {
+	case 0:
+		return
+	case len(f.Names):
+	default:
+		r.skip(f, "declaration of %s shared with unselected fields")
+		return
+	}
+	f.Type = &ast.IndexExpr{
+		X:     &ast.SelectorExpr{X: ast.NewIdent(r.optional()), Sel: ast.NewIdent("Optional")},
+		Index: star.X,
+	}
+	r.changed = true
+}
+
+// compositeLit rewrites the values given to selected fields in a struct
+// literal, dropping nil ones.
+func (r *rewriter) compositeLit(lit *ast.CompositeLit) {
+	t := typeOf(r.pkg.TypesInfo, lit)
+	if t == nil {
+		return
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+	elts := lit.Elts[:0]
+	for i, elt := range lit.Elts {
+		var obj types.Object
+		value := &elt
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				obj = r.pkg.TypesInfo.ObjectOf(key)
+			}
+			value = &kv.Value
+		} else if i < st.NumFields() {
+			obj = st.Field(i)
+		}
+		t, ok := r.target(obj)
+		if !ok {
+			elts = append(elts, elt)
+			continue
+		}
+		if _, keyed := elt.(*ast.KeyValueExpr); keyed && r.isNil(*value) {
+			r.changed = true
+			continue
+		}
+		*value = r.value(t, *value)
+		elts = append(elts, elt)
+	}
+	lit.Elts = elts
+}
+
+// value returns the expression to assign to a converted field in place of
+// the pointer e.
+func (r *rewriter) value(t target, e ast.Expr) ast.Expr {
+	if _, ok := r.ref(e); ok {
+		r.handled[e] = true
+		return e
+	}
+	r.changed = true
+	if r.isNil(e) {
+		return &ast.CallExpr{Fun: &ast.IndexExpr{
+			X:     &ast.SelectorExpr{X: ast.NewIdent(r.optional()), Sel: ast.NewIdent("Empty")},
+			Index: ast.NewIdent(types.TypeString(t.elem, r.qualifier)),
+		}}
+	}
+	if u, ok := ast.Unparen(e).(*ast.UnaryExpr); ok && u.Op == token.AND {
+		if _, ok := ast.Unparen(u.X).(*ast.CompositeLit); ok {
+			return &ast.CallExpr{Fun: r.qualified("Of"), Args: []ast.Expr{u.X}}
+		}
+	}
+	return &ast.CallExpr{Fun: r.qualified("OfNullable"), Args: []ast.Expr{e}}
+}
+
+// ref reports whether e refers to a selected field, and which.
+func (r *rewriter) ref(e ast.Expr) (target, bool) {
+	sel, ok := ast.Unparen(e).(*ast.SelectorExpr)
+	if !ok {
+		return target{}, false
+	}
+	s, ok := r.pkg.TypesInfo.Selections[sel]
+	if !ok || s.Kind() != types.FieldVal {
+		return target{}, false
+	}
+	return r.target(s.Obj())
+}
+
+func (r *rewriter) target(obj types.Object) (target, bool) {
+	v, ok := obj.(*types.Var)
+	if !ok || !v.IsField() {
+		return target{}, false
+	}
+	t, ok := r.targets[r.pkg.Fset.Position(v.Pos())]
+	return t, ok
+}
+
+// markWrite records e, and the field references it is built from, as
+// written to.
+func (r *rewriter) markWrite(e ast.Expr) {
+	for e != nil {
+		r.writes[e] = true
+		switch x := e.(type) {
+		case *ast.ParenExpr:
+			e = x.X
+		case *ast.SelectorExpr:
+			e = x.X
+		case *ast.StarExpr:
+			e = x.X
+		case *ast.IndexExpr:
+			e = x.X
+		default:
+			return
+		}
+	}
+}
+
+func (r *rewriter) isNil(e ast.Expr) bool {
+	return r.pkg.TypesInfo.Types[e].IsNil()
+}
+
+func (r *rewriter) replace(c *astutil.Cursor, n ast.Node) {
+	c.Replace(n)
+	r.changed = true
+}
+
+func (r *rewriter) skip(n ast.Node, format string) {
+	name := ""
+	ast.Inspect(n, func(n ast.Node) bool {
+		if e, ok := n.(ast.Expr); ok && name == "" {
+			if t, ok := r.ref(e); ok {
+				name = t.name
+			}
+		}
+		if f, ok := n.(*ast.Field); ok && len(f.Names) > 0 {
+			for _, id := range f.Names {
+				if t, ok := r.targets[r.pkg.Fset.Position(id.Pos())]; ok {
+					name = t.name
+				}
+			}
+		}
+		return name == ""
+	})
+	r.skipped = append(r.skipped, fmt.Sprintf("%s: cannot rewrite "+format, r.pkg.Fset.Position(n.Pos()), name))
+	if e, ok := n.(ast.Expr); ok {
+		r.handled[e] = true
+	}
+}
+
+// optional returns the name the file imports the optional package under,
+// adding the import if needed.
+func (r *rewriter) optional() string {
+	return r.importName(optionalPath, "optional")
+}
+
+func (r *rewriter) qualified(name string) ast.Expr {
+	return &ast.SelectorExpr{X: ast.NewIdent(r.optional()), Sel: ast.NewIdent(name)}
+}
+
+// qualifier qualifies the types of other packages by the names the file
+// imports them under, adding imports as needed.
+func (r *rewriter) qualifier(p *types.Package) string {
+	if p.Path() == r.pkg.PkgPath {
+		return ""
+	}
+	return r.importName(p.Path(), p.Name())
+}
+
+func (r *rewriter) importName(path, name string) string {
+	for _, spec := range r.file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p == path {
+			if spec.Name != nil {
+				return spec.Name.Name
+			}
+			return name
+		}
+	}
+	astutil.AddImport(r.pkg.Fset, r.file, path)
+	return name
+}
+
+func call(x ast.Expr, method string) ast.Expr {
+	return &ast.CallExpr{Fun: &ast.SelectorExpr{X: x, Sel: ast.NewIdent(method)}}
+}
+
+// typeOf returns the type of the composite literal lit.
+func typeOf(info *types.Info, lit *ast.CompositeLit) types.Type {
+	t := info.TypeOf(lit)
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
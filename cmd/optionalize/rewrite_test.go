@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, src string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "go.mod", "module example.com/app\n\ngo 1.24\n")
+	writeFile(t, dir, "model/user.go", `package model
+
+type Address struct {
+	City string
+}
+
+type User struct {
+	Name     string
+	Nickname *string
+	Address  *Address
+	Age      *int
+}
+
+func (u *User) HasNickname() bool {
+	return u.Nickname != nil
+}
+`)
+	writeFile(t, dir, "api/api.go", `package api
+
+import "example.com/app/model"
+
+func New(name string, nick *string) *model.User {
+	u := &model.User{Name: name, Nickname: nick, Address: &model.Address{City: "Paris"}, Age: nil}
+	if nil == u.Nickname {
+		u.Nickname = nil
+	}
+	return u
+}
+
+func City(u model.User) string {
+	if u.Address == nil {
+		return ""
+	}
+	return u.Address.City
+}
+
+func Reset(u *model.User) {
+	u.Address = nil
+	u.Address.City = "Lyon"
+	*u.Nickname = "x"
+	println(*u.Nickname)
+	take(u.Nickname)
+}
+
+func take(*string) {}
+`)
+
+	res, err := rewrite(dir, []string{"./..."}, []string{"User.Nickname", "example.com/app/model.User.Address"})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	model := string(res.Files[filepath.Join(dir, "model/user.go")])
+	for _, want := range []string{
+		`import "github.com/hermann-craft/optional"`,
+		"Nickname optional.Optional[string]",
+		"Address  optional.Optional[Address]",
+		"Age      *int",
+		"return u.Nickname.IsPresent()",
+	} {
+		if !strings.Contains(model, want) {
+			t.Errorf("Expected model to contain %q, but it did not:\n%s", want, model)
+		}
+	}
+	api := string(res.Files[filepath.Join(dir, "api/api.go")])
+	for _, want := range []string{
+		`"github.com/hermann-craft/optional"`,
+		"Nickname: optional.OfNullable(nick)",
+		`Address: optional.Of(model.Address{City: "Paris"})`,
+		"Age: nil",
+		"if u.Nickname.IsEmpty() {",
+		"u.Nickname = optional.Empty[string]()",
+		"if u.Address.IsEmpty() {",
+		"return u.Address.Get().City",
+		"u.Address = optional.Empty[model.Address]()",
+		"println(u.Nickname.Get())",
+	} {
+		if !strings.Contains(api, want) {
+			t.Errorf("Expected api to contain %q, but it did not:\n%s", want, api)
+		}
+	}
+
+	if len(res.Skipped) != 3 {
+		t.Fatalf("Expected 3 skipped uses, but got %q", res.Skipped)
+	}
+	for i, want := range []string{"use of User.Address", "assignment through User.Nickname", "use of User.Nickname"} {
+		if !strings.HasSuffix(res.Skipped[i], "cannot rewrite "+want) {
+			t.Errorf("Expected skipped use %q, but got %q", want, res.Skipped[i])
+		}
+	}
+}
+
+func TestRewriteErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "go.mod", "module example.com/app\n\ngo 1.24\n")
+	writeFile(t, dir, "a/a.go", "package a\n\ntype User struct {\n\tName string\n\tNick *string\n}\n")
+	writeFile(t, dir, "b/b.go", "package b\n\ntype User struct {\n\tNick *string\n}\n")
+
+	for _, field := range []string{"User", "User.Missing", "User.Name", "User.Nick"} {
+		if _, err := rewrite(dir, []string{"./..."}, []string{field}); err == nil {
+			t.Errorf("Expected an error for %s, but got nil", field)
+		}
+	}
+	if _, err := rewrite(dir, []string{"./..."}, []string{"example.com/app/b.User.Nick"}); err != nil {
+		t.Errorf("Expected no error for a qualified field, but got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+type typeInfo struct {
+	Name   string // e.g. Time
+	Type   string // e.g. time.Time
+	Import string // e.g. time; empty for predeclared types
+}
+
+// parseType interprets a type argument such as "int64", "time.Time" or
+// "github.com/shopspring/decimal.Decimal".
+func parseType(arg string) (typeInfo, error) {
+	path, name := "", arg
+	if i := strings.LastIndex(arg, "."); i >= 0 {
+		path, name = arg[:i], arg[i+1:]
+	}
+	if !token.IsIdentifier(name) || (path != "" && !token.IsExported(name)) {
+		return typeInfo{}, fmt.Errorf("invalid type %q", arg)
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	info := typeInfo{Name: string(unicode.ToUpper(r)) + name[size:], Type: name}
+	if path != "" {
+		info.Import = path
+		info.Type = path[strings.LastIndex(path, "/")+1:] + "." + name
+	}
+	return info, nil
+}
+
+// generate returns the formatted source declaring an optional type in pkg
+// for each of the given inner types.
+func generate(pkg string, args []string) ([]byte, error) {
+	imports := map[string]bool{
+		"database/sql":                      true,
+		"database/sql/driver":               true,
+		"encoding/json":                     true,
+		"fmt":                               true,
+		"github.com/hermann-craft/optional": true,
+	}
+	seen := map[string]bool{}
+	var types []typeInfo
+	for _, arg := range args {
+		info, err := parseType(arg)
+		if err != nil {
+			return nil, err
+		}
+		if seen[info.Name] {
+			return nil, fmt.Errorf("duplicate type name Optional%s", info.Name)
+		}
+		seen[info.Name] = true
+		if info.Import != "" {
+			imports[info.Import] = true
+		}
+		types = append(types, info)
+	}
+	var std, other []string
+	for path := range imports {
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string]any{
+		"Package": pkg,
+		"Std":     std,
+		"Other":   other,
+		"Types":   types,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var tmpl = template.Must(template.New("optionaltypes").Parse(`// Code generated by optionaltypes. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{range .Std}}	"{{.}}"
+{{end}}
+{{range .Other}}	"{{.}}"
+{{end}})
+{{range .Types}}
+// Optional{{.Name}} is a concrete form of optional.Optional[{{.Type}}].
+type Optional{{.Name}} struct {
+	value   {{.Type}}
+	present bool
+}
+
+// Of{{.Name}} returns an Optional{{.Name}} containing v.
+func Of{{.Name}}(v {{.Type}}) Optional{{.Name}} {
+	return Optional{{.Name}}{value: v, present: true}
+}
+
+// From{{.Name}} converts a generic Optional into an Optional{{.Name}}.
+func From{{.Name}}(o optional.Optional[{{.Type}}]) Optional{{.Name}} {
+	if o.IsEmpty() {
+		return Optional{{.Name}}{}
+	}
+	return Of{{.Name}}(o.Get())
+}
+
+// Optional converts o into a generic Optional.
+func (o Optional{{.Name}}) Optional() optional.Optional[{{.Type}}] {
+	if !o.present {
+		return optional.Empty[{{.Type}}]()
+	}
+	return optional.Of(o.value)
+}
+
+// IsPresent returns true if o contains a value.
+func (o Optional{{.Name}}) IsPresent() bool {
+	return o.present
+}
+
+// IsEmpty returns true if o does not contain a value.
+func (o Optional{{.Name}}) IsEmpty() bool {
+	return !o.present
+}
+
+// Get returns the value if present, otherwise it panics.
+func (o Optional{{.Name}}) Get() {{.Type}} {
+	if !o.present {
+		panic("Optional{{.Name}}.Get: no value present")
+	}
+	return o.value
+}
+
+// OrElse returns the value if present, otherwise returns other.
+func (o Optional{{.Name}}) OrElse(other {{.Type}}) {{.Type}} {
+	if o.present {
+		return o.value
+	}
+	return other
+}
+
+// String returns a string representation of o.
+func (o Optional{{.Name}}) String() string {
+	if o.present {
+		return fmt.Sprintf("Optional[%v]", o.value)
+	}
+	return "Optional.empty"
+}
+
+// MarshalJSON encodes the value, or null when o is empty.
+func (o Optional{{.Name}}) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value into o, leaving it empty for null.
+func (o *Optional{{.Name}}) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Optional{{.Name}}{}
+		return nil
+	}
+	var v {{.Type}}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Of{{.Name}}(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, leaving o empty for NULL.
+func (o *Optional{{.Name}}) Scan(src any) error {
+	var n sql.Null[{{.Type}}]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = Optional{{.Name}}{value: n.V, present: n.Valid}
+	return nil
+}
+
+// Value implements driver.Valuer, returning NULL when o is empty.
+func (o Optional{{.Name}}) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+{{end}}`))
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseType(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want typeInfo
+	}{
+		{"string", typeInfo{Name: "String", Type: "string"}},
+		{"time.Time", typeInfo{Name: "Time", Type: "time.Time", Import: "time"}},
+		{"github.com/shopspring/decimal.Decimal", typeInfo{Name: "Decimal", Type: "decimal.Decimal", Import: "github.com/shopspring/decimal"}},
+	}
+	for _, tt := range tests {
+		got, err := parseType(tt.arg)
+		if err != nil || got != tt.want {
+			t.Errorf("Expected %+v for %q, but got %+v (err %v)", tt.want, tt.arg, got, err)
+		}
+	}
+	if _, err := parseType("time.time"); err == nil {
+		t.Errorf("Expected error for unexported qualified type, but got none")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("named", []string{"string", "time.Time"})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	out := string(src)
+	for _, want := range []string{
+		"package named",
+		`"time"`,
+		"type OptionalString struct",
+		"func OfTime(v time.Time) OptionalTime",
+		"func FromString(o optional.Optional[string]) OptionalString",
+		"func (o OptionalTime) Optional() optional.Optional[time.Time]",
+		"func (o *OptionalString) Scan(src any) error",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected generated code to contain %q, but it did not", want)
+		}
+	}
+}
+
+func TestGenerateDuplicate(t *testing.T) {
+	if _, err := generate("named", []string{"int", "int"}); err == nil {
+		t.Errorf("Expected error for duplicate type, but got none")
+	}
+}
@@ -0,0 +1,48 @@
+// Command optionaltypes generates concrete, non-generic optional types for
+// encoders and ORMs that cannot handle generic wrappers.
+//
+// Each argument names an inner type, either a predeclared type or a
+// package-qualified one given by its import path:
+//
+//	//go:generate optionaltypes -package named -output named.go string int64 time.Time
+//
+// For every inner type T with name N the generated code declares OptionalN
+// with JSON and database/sql support, together with OfN, FromN and an
+// Optional method converting to and from optional.Optional[T].
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("optionaltypes: ")
+
+	pkg := flag.String("package", "", "package name of the generated file (required)")
+	output := flag.String("output", "", "output file name; default standard output")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: optionaltypes -package name [-output file] type...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *pkg == "" || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := generate(*pkg, flag.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *output == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
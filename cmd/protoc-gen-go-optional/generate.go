@@ -0,0 +1,247 @@
+package main
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const optionalPackage = protogen.GoImportPath("github.com/hermann-craft/optional")
+
+// generateFile generates the accessors for the messages of f, and the
+// conversions to and from the structs of the domain package if it is not
+// empty. It returns nil if there is nothing to generate.
+func generateFile(gen *protogen.Plugin, f *protogen.File, domain protogen.GoImportPath) *protogen.GeneratedFile {
+	messages := allMessages(f.Messages)
+	var fields []*protogen.Field
+	for _, m := range messages {
+		for _, field := range m.Fields {
+			if field.Desc.HasPresence() {
+				fields = append(fields, field)
+			}
+		}
+	}
+	if len(fields) == 0 && (domain == "" || len(messages) == 0) {
+		return nil
+	}
+	g := gen.NewGeneratedFile(f.GeneratedFilenamePrefix+"_optional.pb.go", f.GoImportPath)
+	g.P("// Code generated by protoc-gen-go-optional. DO NOT EDIT.")
+	g.P("// source: ", f.Desc.Path())
+	g.P()
+	g.P("package ", f.GoPackageName)
+	for _, field := range fields {
+		g.P()
+		generateField(g, field)
+	}
+	if domain != "" {
+		for _, m := range messages {
+			g.P()
+			generateConversions(g, f, m, domain)
+		}
+	}
+	return g
+}
+
+// allMessages returns messages and their nested messages, leaving out the
+// entries of map fields.
+func allMessages(messages []*protogen.Message) []*protogen.Message {
+	var all []*protogen.Message
+	for _, m := range messages {
+		if m.Desc.IsMapEntry() {
+			continue
+		}
+		all = append(all, m)
+		all = append(all, allMessages(m.Messages)...)
+	}
+	return all
+}
+
+func generateField(g *protogen.GeneratedFile, field *protogen.Field) {
+	m := field.Parent.GoIdent.GoName
+	name := field.GoName
+	typ := goType(g, field)
+	optionalOf := g.QualifiedGoIdent(optionalPackage.Ident("Optional")) + "[" + typ + "]"
+	empty := g.QualifiedGoIdent(optionalPackage.Ident("Empty")) + "[" + typ + "]()"
+	of := g.QualifiedGoIdent(optionalPackage.Ident("Of"))
+	// Message and bytes fields are nil when absent, scalars are pointers.
+	nilable := isMessage(field) || field.Desc.Kind() == protoreflect.BytesKind
+
+	g.P("// Optional", name, " returns the value of the ", field.Desc.Name(), " field, or an empty")
+	g.P("// Optional if it is not set.")
+	g.P("func (x *", m, ") Optional", name, "() ", optionalOf, " {")
+	switch {
+	case isOneofMember(field):
+		// The wrapper type alone tells that the member is set, even to nil.
+		g.P("if w, ok := x.Get", field.Oneof.GoName, "().(*", field.GoIdent, "); ok {")
+		g.P("return ", g.QualifiedGoIdent(optionalPackage.Ident("OfNullable")), "(&w.", name, ")")
+		g.P("}")
+		g.P("return ", empty)
+	case nilable:
+		g.P("if x == nil || x.", name, " == nil {")
+		g.P("return ", empty)
+		g.P("}")
+		g.P("return ", of, "(x.", name, ")")
+	default:
+		g.P("if x == nil || x.", name, " == nil {")
+		g.P("return ", empty)
+		g.P("}")
+		g.P("return ", of, "(*x.", name, ")")
+	}
+	g.P("}")
+	g.P()
+
+	g.P("// SetOptional", name, " sets the ", field.Desc.Name(), " field to the value of o, or clears it")
+	g.P("// if o is empty.")
+	g.P("func (x *", m, ") SetOptional", name, "(o ", optionalOf, ") {")
+	switch {
+	case isOneofMember(field):
+		g.P("if o.IsPresent() {")
+		g.P("x.", field.Oneof.GoName, " = &", field.GoIdent, "{", name, ": o.Get()}")
+		g.P("} else if _, ok := x.", field.Oneof.GoName, ".(*", field.GoIdent, "); ok {")
+		g.P("x.", field.Oneof.GoName, " = nil")
+		g.P("}")
+	case nilable:
+		g.P("x.", name, " = o.OrZero()")
+	default:
+		g.P("if o.IsEmpty() {")
+		g.P("x.", name, " = nil")
+		g.P("return")
+		g.P("}")
+		g.P("v := o.Get()")
+		g.P("x.", name, " = &v")
+	}
+	g.P("}")
+}
+
+// generateConversions generates the conversions between the message m and
+// the struct of the same name in the domain package. Fields with presence
+// map to Optional fields through the generated accessors, and other fields
+// are copied. Messages and enums of the same file, alone, in Optionals or in
+// lists, are converted to the domain types of the same name as well.
+func generateConversions(g *protogen.GeneratedFile, f *protogen.File, m *protogen.Message, domain protogen.GoImportPath) {
+	name := m.GoIdent.GoName
+	d := g.QualifiedGoIdent(domain.Ident(name))
+	mapFn := g.QualifiedGoIdent(optionalPackage.Ident("Map"))
+
+	g.P("// ToDomain converts x to a ", d, ".")
+	g.P("func (x *", name, ") ToDomain() ", d, " {")
+	g.P("var d ", d)
+	g.P("if x == nil {")
+	g.P("return d")
+	g.P("}")
+	for _, field := range m.Fields {
+		message, enum := localMessage(f, field), localEnum(f, field)
+		var e, de string
+		if enum {
+			e, de = field.Enum.GoIdent.GoName, g.QualifiedGoIdent(domain.Ident(field.Enum.GoIdent.GoName))
+		}
+		switch {
+		case field.Desc.IsList() && message:
+			g.P("for _, v := range x.", field.GoName, " {")
+			g.P("d.", field.GoName, " = append(d.", field.GoName, ", v.ToDomain())")
+			g.P("}")
+		case field.Desc.IsList() && enum:
+			g.P("for _, v := range x.", field.GoName, " {")
+			g.P("d.", field.GoName, " = append(d.", field.GoName, ", ", de, "(v))")
+			g.P("}")
+		case field.Desc.IsList() || field.Desc.IsMap():
+			g.P("d.", field.GoName, " = x.Get", field.GoName, "()")
+		case !field.Desc.HasPresence() && enum:
+			g.P("d.", field.GoName, " = ", de, "(x.Get", field.GoName, "())")
+		case !field.Desc.HasPresence():
+			g.P("d.", field.GoName, " = x.Get", field.GoName, "()")
+		case message:
+			g.P("d.", field.GoName, " = ", mapFn, "(x.Optional", field.GoName, "(), (*", field.Message.GoIdent.GoName, ").ToDomain)")
+		case enum:
+			g.P("d.", field.GoName, " = ", mapFn, "(x.Optional", field.GoName, "(), func(v ", e, ") ", de, " { return ", de, "(v) })")
+		default:
+			g.P("d.", field.GoName, " = x.Optional", field.GoName, "()")
+		}
+	}
+	g.P("return d")
+	g.P("}")
+	g.P()
+
+	g.P("// ", name, "FromDomain converts d to a ", name, ".")
+	g.P("func ", name, "FromDomain(d ", d, ") *", name, " {")
+	g.P("x := &", name, "{}")
+	for _, field := range m.Fields {
+		message, enum := localMessage(f, field), localEnum(f, field)
+		var e, de string
+		if enum {
+			e, de = field.Enum.GoIdent.GoName, g.QualifiedGoIdent(domain.Ident(field.Enum.GoIdent.GoName))
+		}
+		switch {
+		case field.Desc.IsList() && message:
+			g.P("for _, v := range d.", field.GoName, " {")
+			g.P("x.", field.GoName, " = append(x.", field.GoName, ", ", field.Message.GoIdent.GoName, "FromDomain(v))")
+			g.P("}")
+		case field.Desc.IsList() && enum:
+			g.P("for _, v := range d.", field.GoName, " {")
+			g.P("x.", field.GoName, " = append(x.", field.GoName, ", ", e, "(v))")
+			g.P("}")
+		case field.Desc.IsList() || field.Desc.IsMap():
+			g.P("x.", field.GoName, " = d.", field.GoName)
+		case !field.Desc.HasPresence() && enum:
+			g.P("x.", field.GoName, " = ", e, "(d.", field.GoName, ")")
+		case !field.Desc.HasPresence():
+			g.P("x.", field.GoName, " = d.", field.GoName)
+		case message:
+			g.P("x.SetOptional", field.GoName, "(", mapFn, "(d.", field.GoName, ", ", field.Message.GoIdent.GoName, "FromDomain))")
+		case enum:
+			g.P("x.SetOptional", field.GoName, "(", mapFn, "(d.", field.GoName, ", func(v ", de, ") ", e, " { return ", e, "(v) }))")
+		default:
+			g.P("x.SetOptional", field.GoName, "(d.", field.GoName, ")")
+		}
+	}
+	g.P("return x")
+	g.P("}")
+}
+
+func isMessage(field *protogen.Field) bool {
+	return field.Desc.Kind() == protoreflect.MessageKind || field.Desc.Kind() == protoreflect.GroupKind
+}
+
+// isOneofMember reports whether field is a member of a real oneof, rather
+// than a proto3 optional field.
+func isOneofMember(field *protogen.Field) bool {
+	return field.Oneof != nil && !field.Oneof.Desc.IsSynthetic()
+}
+
+// localMessage reports whether field holds a message declared in f, which
+// has domain conversions generated along with it.
+func localMessage(f *protogen.File, field *protogen.Field) bool {
+	return isMessage(field) && !field.Desc.IsMap() && field.Message.Desc.ParentFile().Path() == f.Desc.Path()
+}
+
+// localEnum reports whether field holds an enum declared in f, which has a
+// domain type of the same name.
+func localEnum(f *protogen.File, field *protogen.Field) bool {
+	return field.Enum != nil && field.Enum.Desc.ParentFile().Path() == f.Desc.Path()
+}
+
+// goType returns the Go type of the value of field.
+func goType(g *protogen.GeneratedFile, field *protogen.Field) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.EnumKind:
+		return g.QualifiedGoIdent(field.Enum.GoIdent)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "[]byte"
+	}
+	return "*" + g.QualifiedGoIdent(field.Message.GoIdent)
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     typ.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+func repeated(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return f
+}
+
+func inOneof(f *descriptorpb.FieldDescriptorProto, index int32, synthetic bool) *descriptorpb.FieldDescriptorProto {
+	f.OneofIndex = proto.Int32(index)
+	if synthetic {
+		f.Proto3Optional = proto.Bool(true)
+	}
+	return f
+}
+
+func userFile() *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("user.proto"),
+		Package: proto.String("example"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/userpb")},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name:  proto.String("Role"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{{Name: proto.String("ROLE_UNSPECIFIED"), Number: proto.Int32(0)}},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+					inOneof(field("nickname", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""), 1, true),
+					inOneof(field("role", 3, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".example.Role"), 2, true),
+					field("address", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".example.Address"),
+					inOneof(field("email", 5, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""), 0, false),
+					inOneof(field("postal", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".example.Address"), 0, false),
+					repeated(field("previous", 7, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".example.Address")),
+					repeated(field("tags", 8, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")),
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{
+					{Name: proto.String("contact")},
+					{Name: proto.String("_nickname")},
+					{Name: proto.String("_role")},
+				},
+			},
+			{
+				Name:  proto.String("Address"),
+				Field: []*descriptorpb.FieldDescriptorProto{field("city", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")},
+			},
+		},
+	}
+}
+
+func run(t *testing.T, file *descriptorpb.FileDescriptorProto, domain protogen.GoImportPath) map[string]string {
+	t.Helper()
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{file.GetName()},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{file},
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, f := range gen.Files {
+		if f.Generate {
+			generateFile(gen, f, domain)
+		}
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		t.Fatalf("Expected no error, but got %s", resp.GetError())
+	}
+	files := map[string]string{}
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+	return files
+}
+
+func TestGenerate(t *testing.T) {
+	files := run(t, userFile(), "")
+	out, ok := files["example.com/userpb/user_optional.pb.go"]
+	if !ok {
+		t.Fatalf("Expected user_optional.pb.go, but got %v", files)
+	}
+	for _, want := range []string{
+		"// Code generated by protoc-gen-go-optional. DO NOT EDIT.",
+		"package userpb",
+		`optional "github.com/hermann-craft/optional"`,
+		"func (x *User) OptionalNickname() optional.Optional[string] {",
+		"return optional.Of(*x.Nickname)",
+		"func (x *User) SetOptionalNickname(o optional.Optional[string]) {",
+		"func (x *User) OptionalRole() optional.Optional[Role] {",
+		"func (x *User) OptionalAddress() optional.Optional[*Address] {",
+		"x.Address = o.OrZero()",
+		"if w, ok := x.GetContact().(*User_Email); ok {",
+		"if w, ok := x.GetContact().(*User_Postal); ok {",
+		"return optional.OfNullable(&w.Postal)",
+		"x.Contact = &User_Postal{Postal: o.Get()}",
+		"} else if _, ok := x.Contact.(*User_Email); ok {",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected the output to contain %q, but got:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"OptionalId", "OptionalCity", "OptionalPrevious", "ToDomain"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("Expected no accessor %s for a field without presence", unwanted)
+		}
+	}
+}
+
+func TestGenerateWithoutPresence(t *testing.T) {
+	file := userFile()
+	file.MessageType = file.MessageType[1:]
+	if files := run(t, file, ""); len(files) != 0 {
+		t.Errorf("Expected no output, but got %v", files)
+	}
+}
+
+func TestGenerateConversions(t *testing.T) {
+	out := run(t, userFile(), "example.com/user")["example.com/userpb/user_optional.pb.go"]
+	for _, want := range []string{
+		`user "example.com/user"`,
+		"func (x *User) ToDomain() user.User {",
+		"d.Id = x.GetId()",
+		"d.Nickname = x.OptionalNickname()",
+		"d.Address = optional.Map(x.OptionalAddress(), (*Address).ToDomain)",
+		"d.Postal = optional.Map(x.OptionalPostal(), (*Address).ToDomain)",
+		"d.Previous = append(d.Previous, v.ToDomain())",
+		"d.Tags = x.GetTags()",
+		"func UserFromDomain(d user.User) *User {",
+		"x.Id = d.Id",
+		"x.SetOptionalNickname(d.Nickname)",
+		"x.SetOptionalAddress(optional.Map(d.Address, AddressFromDomain))",
+		"x.Previous = append(x.Previous, AddressFromDomain(v))",
+		"func (x *Address) ToDomain() user.Address {",
+		"func AddressFromDomain(d user.Address) *Address {",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected the output to contain %q, but got:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateConversionsWithoutPresence(t *testing.T) {
+	file := userFile()
+	file.MessageType = file.MessageType[1:]
+	out := run(t, file, "example.com/user")["example.com/userpb/user_optional.pb.go"]
+	if !strings.Contains(out, "func AddressFromDomain(d user.Address) *Address {") {
+		t.Errorf("Expected the conversions, but got:\n%s", out)
+	}
+}
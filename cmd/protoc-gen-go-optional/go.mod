@@ -0,0 +1,5 @@
+module github.com/hermann-craft/optional/cmd/protoc-gen-go-optional
+
+go 1.24.0
+
+require google.golang.org/protobuf v1.36.12
@@ -0,0 +1,50 @@
+// Command protoc-gen-go-optional is a protoc plugin generating Optional
+// accessors for the fields with presence of messages generated by
+// protoc-gen-go: proto3 and proto2 optional fields, message fields and the
+// members of oneofs.
+//
+// For every such field X of type T of a message M it generates
+//
+//	func (x *M) OptionalX() optional.Optional[T]
+//	func (x *M) SetOptionalX(o optional.Optional[T])
+//
+// so that mapping between messages and domain structs with Optional fields
+// needs no Has checks or pointer juggling:
+//
+//	user.Nickname = msg.OptionalNickname()
+//	msg.SetOptionalNickname(user.Nickname)
+//
+// With the domain parameter set to the import path of a package declaring
+// hand-written structs named like the messages, it also generates the
+// conversions between them:
+//
+//	func (x *M) ToDomain() domain.M
+//	func MFromDomain(d domain.M) *M
+//
+// Fields with presence map to Optional fields of the struct, messages of the
+// same file to their structs, and other fields are copied as they are.
+//
+// The code goes into a <file>_optional.pb.go file next to the output of
+// protoc-gen-go:
+//
+//	protoc --go_out=. --go-optional_out=. --go-optional_opt=domain=example.com/app/user user.proto
+package main
+
+import (
+	"flag"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func main() {
+	var flags flag.FlagSet
+	domain := flags.String("domain", "", "import path of the package with the domain structs")
+	protogen.Options{ParamFunc: flags.Set}.Run(func(gen *protogen.Plugin) error {
+		for _, f := range gen.Files {
+			if f.Generate {
+				generateFile(gen, f, protogen.GoImportPath(*domain))
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,40 @@
+package optional
+
+import "cmp"
+
+// Equal reports whether a and b are both empty, or both present with equal
+// values.
+func Equal[T comparable](a, b Optional[T]) bool {
+	return EqualFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// EqualFunc is like Equal but compares the values with eq, so it also
+// works for values that are not comparable, such as slices and maps.
+func EqualFunc[T any](a, b Optional[T], eq func(T, T) bool) bool {
+	if a.IsEmpty() || b.IsEmpty() {
+		return a.IsEmpty() == b.IsEmpty()
+	}
+	return eq(*a.value, *b.value)
+}
+
+// Compare returns -1, 0 or +1 depending on whether a is less than, equal to
+// or greater than b. An empty Optional is less than any present one, and two
+// empty Optionals are equal. Compare can be passed to slices.SortFunc,
+// slices.BinarySearchFunc and the other cmp-based APIs of the standard
+// library.
+func Compare[T cmp.Ordered](a, b Optional[T]) int {
+	return CompareFunc(a, b, cmp.Compare[T])
+}
+
+// CompareFunc is like Compare but compares present values with cmp.
+func CompareFunc[T any](a, b Optional[T], cmp func(T, T) int) int {
+	switch {
+	case a.IsEmpty() && b.IsEmpty():
+		return 0
+	case a.IsEmpty():
+		return -1
+	case b.IsEmpty():
+		return 1
+	}
+	return cmp(*a.value, *b.value)
+}
@@ -0,0 +1,71 @@
+package optional
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b Optional[int]
+		want bool
+	}{
+		{Of(1), Of(1), true},
+		{Of(1), Of(2), false},
+		{Of(0), Empty[int](), false},
+		{Empty[int](), Of(0), false},
+		{Empty[int](), Empty[int](), true},
+	}
+	for _, tt := range tests {
+		if got := Equal(tt.a, tt.b); got != tt.want {
+			t.Errorf("Expected Equal(%v, %v) to be %v, but got %v", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	a, b := Of([]int{1, 2}), Of([]int{1, 2})
+	if !EqualFunc(a, b, slices.Equal[[]int]) {
+		t.Errorf("Expected %v and %v to be equal", a, b)
+	}
+	if EqualFunc(a, Of([]int{2}), slices.Equal[[]int]) {
+		t.Errorf("Expected %v and [2] to differ", a)
+	}
+	if EqualFunc(a, Empty[[]int](), slices.Equal[[]int]) {
+		t.Errorf("Expected %v and an empty Optional to differ", a)
+	}
+	if !EqualFunc(Empty[[]int](), Empty[[]int](), slices.Equal[[]int]) {
+		t.Errorf("Expected two empty Optionals to be equal")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b Optional[int]
+		want int
+	}{
+		{Of(1), Of(2), -1},
+		{Of(2), Of(1), 1},
+		{Of(1), Of(1), 0},
+		{Empty[int](), Of(-5), -1},
+		{Of(-5), Empty[int](), 1},
+		{Empty[int](), Empty[int](), 0},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Expected Compare(%v, %v) to be %d, but got %d", tt.a, tt.b, tt.want, got)
+		}
+	}
+}
+
+func TestCompareSort(t *testing.T) {
+	s := []Optional[string]{Of("b"), Empty[string](), Of("a")}
+	slices.SortFunc(s, Compare[string])
+	if s[0].IsPresent() || s[1].OrElse("") != "a" || s[2].OrElse("") != "b" {
+		t.Errorf("Expected [Optional.empty Optional[a] Optional[b]], but got %v", s)
+	}
+	i, found := slices.BinarySearchFunc(s, Of("b"), Compare[string])
+	if !found || i != 2 {
+		t.Errorf("Expected to find Optional[b] at 2, but got %d, %v", i, found)
+	}
+}
@@ -0,0 +1,56 @@
+package optional
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// FirstPresentCtx runs the suppliers concurrently and returns the first
+// present result, cancelling the context passed to the others. It returns an
+// empty Optional once every supplier has returned an empty one, or when ctx
+// is done first. Suppliers should return promptly once their context is
+// cancelled; FirstPresentCtx does not wait for them.
+func FirstPresentCtx[T any](ctx context.Context, suppliers ...func(context.Context) Optional[T]) Optional[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan Optional[T], len(suppliers))
+	for _, supplier := range suppliers {
+		go func() { results <- supplier(ctx) }()
+	}
+	for range suppliers {
+		select {
+		case o := <-results:
+			if o.IsPresent() {
+				return o
+			}
+		case <-ctx.Done():
+			return Empty[T]()
+		}
+	}
+	return Empty[T]()
+}
+
+// ResolveAll runs the suppliers concurrently and returns their results in
+// the order of the suppliers.
+func ResolveAll[T any](ctx context.Context, suppliers ...func(context.Context) Optional[T]) []Optional[T] {
+	return ResolveAllLimit(ctx, -1, suppliers...)
+}
+
+// ResolveAllLimit is like ResolveAll but runs at most limit suppliers at a
+// time. A limit of zero or less means no limit.
+func ResolveAllLimit[T any](ctx context.Context, limit int, suppliers ...func(context.Context) Optional[T]) []Optional[T] {
+	results := make([]Optional[T], len(suppliers))
+	g, ctx := errgroup.WithContext(ctx)
+	if limit > 0 {
+		g.SetLimit(limit)
+	}
+	for i, supplier := range suppliers {
+		g.Go(func() error {
+			results[i] = supplier(ctx)
+			return nil
+		})
+	}
+	g.Wait()
+	return results
+}
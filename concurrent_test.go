@@ -0,0 +1,112 @@
+package optional
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFirstPresentCtx(t *testing.T) {
+	cancelled := make(chan struct{})
+	slow := func(ctx context.Context) Optional[string] {
+		<-ctx.Done()
+		close(cancelled)
+		return Of("slow")
+	}
+	empty := func(context.Context) Optional[string] { return Empty[string]() }
+	fast := func(context.Context) Optional[string] {
+		time.Sleep(10 * time.Millisecond)
+		return Of("fast")
+	}
+
+	if got := FirstPresentCtx(context.Background(), slow, empty, fast); got.OrElse("") != "fast" {
+		t.Errorf("Expected Optional[fast], but got %v", got)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Errorf("Expected the remaining suppliers to be cancelled")
+	}
+}
+
+func TestFirstPresentCtxAllEmpty(t *testing.T) {
+	empty := func(context.Context) Optional[int] { return Empty[int]() }
+	if got := FirstPresentCtx(context.Background(), empty, empty); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	if got := FirstPresentCtx[int](context.Background()); got.IsPresent() {
+		t.Errorf("Expected an empty Optional without suppliers, but got %v", got)
+	}
+}
+
+func TestFirstPresentCtxDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	block := func(ctx context.Context) Optional[int] {
+		<-ctx.Done()
+		return Empty[int]()
+	}
+	if got := FirstPresentCtx(ctx, block); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestResolveAll(t *testing.T) {
+	supplier := func(i int) func(context.Context) Optional[int] {
+		return func(context.Context) Optional[int] {
+			if i%2 == 0 {
+				return Empty[int]()
+			}
+			return Of(i)
+		}
+	}
+	got := ResolveAll(context.Background(), supplier(0), supplier(1), supplier(2), supplier(3))
+	if len(got) != 4 {
+		t.Fatalf("Expected 4 results, but got %d", len(got))
+	}
+	for i, o := range got {
+		if o.IsPresent() != (i%2 == 1) || o.OrElse(i) != i {
+			t.Errorf("Expected result %d to match its supplier, but got %v", i, o)
+		}
+	}
+}
+
+func TestResolveAllLimit(t *testing.T) {
+	var mu sync.Mutex
+	running, peak := 0, 0
+	supplier := func(context.Context) Optional[int] {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return Of(1)
+	}
+	suppliers := []func(context.Context) Optional[int]{supplier, supplier, supplier, supplier, supplier}
+	got := ResolveAllLimit(context.Background(), 2, suppliers...)
+	if len(got) != 5 {
+		t.Fatalf("Expected 5 results, but got %d", len(got))
+	}
+	if peak > 2 {
+		t.Errorf("Expected at most 2 suppliers at a time, but got %d", peak)
+	}
+}
+
+func TestResolveAllLimitZero(t *testing.T) {
+	done := make(chan []Optional[int])
+	go func() {
+		done <- ResolveAllLimit(context.Background(), 0, func(context.Context) Optional[int] { return Of(1) })
+	}()
+	select {
+	case got := <-done:
+		if len(got) != 1 || got[0].OrZero() != 1 {
+			t.Errorf("Expected [Optional[1]], but got %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a zero limit to mean no limit, but ResolveAllLimit blocked")
+	}
+}
@@ -0,0 +1,127 @@
+package optional
+
+import (
+	"math"
+	"reflect"
+)
+
+// ConfigSource is a configuration store that may or may not hold a key.
+// Koanf and Viper adapt the common libraries.
+type ConfigSource interface {
+	Lookup(key string) (any, bool)
+}
+
+// ConfGet returns the configuration value for key as a T, or an empty
+// Optional if the key is missing, so a missing key is no longer confused
+// with one set to the zero value. The value is converted when needed: from
+// a string using the rules of the default struct tag of FillDefaults (as for
+// environment variables), and between numeric types when no precision is
+// lost (as for numbers decoded from JSON or YAML). A value that cannot be
+// converted yields an empty Optional.
+func ConfGet[T any](k ConfigSource, key string) Optional[T] {
+	raw, ok := k.Lookup(key)
+	if !ok || raw == nil {
+		return Empty[T]()
+	}
+	if v, ok := raw.(T); ok {
+		return Of(v)
+	}
+	t := reflect.TypeFor[T]()
+	v := reflect.ValueOf(raw)
+	if cv, ok := convertTo(v, t); ok {
+		return Of(cv.Interface().(T))
+	}
+	if s, ok := raw.(string); ok {
+		if cv, err := parseDefault(s, t); err == nil {
+			return Of(cv.Interface().(T))
+		}
+		return Empty[T]()
+	}
+	if isNumber(v.Kind()) && isNumber(t.Kind()) {
+		if cv, ok := convertNumber(v, t); ok {
+			return Of(cv.Interface().(T))
+		}
+	}
+	return Empty[T]()
+}
+
+func isNumber(k reflect.Kind) bool {
+	return reflect.Int <= k && k <= reflect.Float64
+}
+
+// convertNumber converts the number v to the numeric type t, and reports
+// false if the value is out of the range of t or would lose precision.
+func convertNumber(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	cv := reflect.New(t).Elem()
+	switch {
+	case v.CanFloat():
+		f := v.Float()
+		switch {
+		case cv.CanInt() && f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !cv.OverflowInt(int64(f)):
+			cv.SetInt(int64(f))
+		case cv.CanUint() && f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !cv.OverflowUint(uint64(f)):
+			cv.SetUint(uint64(f))
+		case cv.CanFloat() && !cv.OverflowFloat(f) && v.Convert(t).Float() == f:
+			cv.SetFloat(f)
+		default:
+			return reflect.Value{}, false
+		}
+	case v.CanInt() && cv.CanInt() && !cv.OverflowInt(v.Int()):
+		cv.SetInt(v.Int())
+	case v.CanInt() && cv.CanUint() && v.Int() >= 0 && !cv.OverflowUint(uint64(v.Int())):
+		cv.SetUint(uint64(v.Int()))
+	case v.CanUint() && cv.CanUint() && !cv.OverflowUint(v.Uint()):
+		cv.SetUint(v.Uint())
+	case v.CanUint() && cv.CanInt() && v.Uint() <= math.MaxInt64 && !cv.OverflowInt(int64(v.Uint())):
+		cv.SetInt(int64(v.Uint()))
+	case cv.CanFloat():
+		cv = v.Convert(t)
+		if !cv.Convert(v.Type()).Equal(v) {
+			return reflect.Value{}, false
+		}
+	default:
+		return reflect.Value{}, false
+	}
+	return cv, true
+}
+
+// Koanf adapts a *koanf.Koanf to a ConfigSource.
+func Koanf(k interface {
+	Exists(key string) bool
+	Get(key string) any
+}) ConfigSource {
+	return configFunc(func(key string) (any, bool) {
+		if !k.Exists(key) {
+			return nil, false
+		}
+		return k.Get(key), true
+	})
+}
+
+// Viper adapts a *viper.Viper to a ConfigSource.
+func Viper(v interface {
+	IsSet(key string) bool
+	Get(key string) any
+}) ConfigSource {
+	return configFunc(func(key string) (any, bool) {
+		if !v.IsSet(key) {
+			return nil, false
+		}
+		return v.Get(key), true
+	})
+}
+
+// ConfigMap is a ConfigSource backed by a map, handy for tests.
+type ConfigMap map[string]any
+
+// Lookup returns the value stored for key.
+func (m ConfigMap) Lookup(key string) (any, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+type configFunc func(key string) (any, bool)
+
+func (f configFunc) Lookup(key string) (any, bool) {
+	return f(key)
+}
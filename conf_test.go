@@ -0,0 +1,81 @@
+package optional
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeKoanf map[string]any
+
+func (k fakeKoanf) Exists(key string) bool { _, ok := k[key]; return ok }
+func (k fakeKoanf) Get(key string) any     { return k[key] }
+
+type fakeViper map[string]any
+
+func (v fakeViper) IsSet(key string) bool { _, ok := v[key]; return ok }
+func (v fakeViper) Get(key string) any    { return v[key] }
+
+func TestConfGet(t *testing.T) {
+	k := ConfigMap{
+		"name":    "app",
+		"port":    "8080",
+		"workers": float64(4),
+		"ratio":   float64(0.5),
+		"debug":   false,
+		"timeout": "30s",
+		"level":   int64(3),
+	}
+	if got := ConfGet[string](k, "name"); got.OrElse("") != "app" {
+		t.Errorf("Expected Optional[app], but got %v", got)
+	}
+	if got := ConfGet[int](k, "port"); got.OrElse(0) != 8080 {
+		t.Errorf("Expected Optional[8080], but got %v", got)
+	}
+	if got := ConfGet[int](k, "workers"); got.OrElse(0) != 4 {
+		t.Errorf("Expected Optional[4], but got %v", got)
+	}
+	if got := ConfGet[int](k, "ratio"); got.IsPresent() {
+		t.Errorf("Expected a lossy conversion to be empty, but got %v", got)
+	}
+	if got := ConfGet[bool](k, "debug"); !got.IsPresent() || got.Get() {
+		t.Errorf("Expected Optional[false], but got %v", got)
+	}
+	if got := ConfGet[time.Duration](k, "timeout"); got.OrElse(0) != 30*time.Second {
+		t.Errorf("Expected Optional[30s], but got %v", got)
+	}
+	if got := ConfGet[int32](k, "level"); got.OrElse(0) != 3 {
+		t.Errorf("Expected Optional[3], but got %v", got)
+	}
+	for _, raw := range []any{-1, int64(-1), float64(-1), float64(1 << 64)} {
+		if got := ConfGet[uint](ConfigMap{"n": raw}, "n"); got.IsPresent() {
+			t.Errorf("Expected %v (%T) not to convert to uint, but got %v", raw, raw, got)
+		}
+	}
+	if got := ConfGet[uint8](ConfigMap{"n": 256}, "n"); got.IsPresent() {
+		t.Errorf("Expected an overflow to be empty, but got %v", got)
+	}
+	if got := ConfGet[float32](k, "ratio"); got.OrElse(0) != 0.5 {
+		t.Errorf("Expected Optional[0.5], but got %v", got)
+	}
+	if got := ConfGet[string](k, "missing"); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	if got := ConfGet[int](k, "name"); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestConfAdapters(t *testing.T) {
+	values := map[string]any{"port": 0}
+	for name, src := range map[string]ConfigSource{
+		"Koanf": Koanf(fakeKoanf(values)),
+		"Viper": Viper(fakeViper(values)),
+	} {
+		if got := ConfGet[int](src, "port"); !got.IsPresent() || got.Get() != 0 {
+			t.Errorf("%s: Expected Optional[0], but got %v", name, got)
+		}
+		if got := ConfGet[int](src, "host"); got.IsPresent() {
+			t.Errorf("%s: Expected an empty Optional, but got %v", name, got)
+		}
+	}
+}
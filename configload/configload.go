@@ -0,0 +1,239 @@
+// Package configload populates a struct of Optional fields from layered
+// sources, such as defaults, a file, the environment and command-line flags,
+// and reports which source each field came from.
+//
+//	type Config struct {
+//		Host optional.Optional[string]
+//		Port optional.Optional[int] `env:"PORT" flag:"port"`
+//	}
+//
+//	var cfg Config
+//	prov, err := configload.Load(&cfg,
+//		configload.Defaults(Config{Port: optional.Of(8080)}),
+//		configload.JSONFile("config.json"),
+//		configload.Env("APP"),
+//		configload.Flags(flag.CommandLine),
+//	)
+//
+// Later sources take precedence: a field present in a later source replaces
+// the value from an earlier one. Fields no source sets stay empty, so
+// whether a setting was actually provided is always answerable.
+package configload
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/hermann-craft/optional/reflectopt"
+)
+
+// Source provides configuration values. Load sets the Optional fields of
+// the struct dst points to for which the source has a value; dst is a fresh
+// zero struct of the loaded type.
+type Source interface {
+	Name() string
+	Load(dst any) error
+}
+
+// Provenance maps the dotted path of every field set by Load to the name of
+// the source its value came from.
+type Provenance map[string]string
+
+// Load fills the struct dst points to from sources, later sources taking
+// precedence, and returns the provenance of every field it set. Nested
+// structs are filled field by field.
+func Load(dst any, sources ...Source) (Provenance, error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("configload: destination must be a non-nil pointer to a struct, got %T", dst)
+	}
+	prov := Provenance{}
+	for _, src := range sources {
+		layer := reflect.New(v.Type().Elem())
+		if err := src.Load(layer.Interface()); err != nil {
+			return prov, fmt.Errorf("configload: %s: %w", src.Name(), err)
+		}
+		overlay(v.Elem(), layer.Elem(), "", src.Name(), prov)
+	}
+	return prov, nil
+}
+
+// overlay copies the present Optional fields of src into dst.
+func overlay(dst, src reflect.Value, path, name string, prov Provenance) {
+	eachField(dst.Type(), path, func(index []int, fieldPath string, _ reflect.StructField) {
+		if isPresent(src.FieldByIndex(index)) {
+			dst.FieldByIndex(index).Set(src.FieldByIndex(index))
+			prov[fieldPath] = name
+		}
+	})
+}
+
+// eachField calls fn for every exported Optional field of the struct type
+// t, descending into nested structs.
+func eachField(t reflect.Type, path string, fn func(index []int, path string, f reflect.StructField)) {
+	var walk func(t reflect.Type, index []int, path string)
+	walk = func(t reflect.Type, index []int, path string) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fi := append(index[:len(index):len(index)], i)
+			switch {
+			case reflectopt.IsOptionalType(f.Type):
+				fn(fi, path+f.Name, f)
+			case f.Type.Kind() == reflect.Struct:
+				walk(f.Type, fi, path+f.Name+".")
+			}
+		}
+	}
+	walk(t, nil, path)
+}
+
+func isPresent(v reflect.Value) bool {
+	_, ok := reflectopt.ValueOf(v)
+	return ok
+}
+
+type defaults struct {
+	value any
+}
+
+// Defaults returns a Source providing the present Optional fields of the
+// struct value v, which must be of the loaded type.
+func Defaults(v any) Source {
+	return defaults{value: v}
+}
+
+func (defaults) Name() string { return "defaults" }
+
+func (d defaults) Load(dst any) error {
+	v := reflect.ValueOf(dst).Elem()
+	src := reflect.Indirect(reflect.ValueOf(d.value))
+	if src.Type() != v.Type() {
+		return fmt.Errorf("defaults of type %s cannot fill %s", src.Type(), v.Type())
+	}
+	v.Set(src)
+	return nil
+}
+
+type jsonFile struct {
+	path string
+}
+
+// JSONFile returns a Source decoding the JSON file at path. Keys missing
+// from the file leave their fields empty, and a missing file provides no
+// values.
+func JSONFile(path string) Source {
+	return jsonFile{path: path}
+}
+
+func (f jsonFile) Name() string { return f.path }
+
+func (f jsonFile) Load(dst any) error {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+type env struct {
+	prefix string
+}
+
+// Env returns a Source reading environment variables. A field is read from
+// the variable named by its env tag or, by default, from its path in upper
+// snake case, as in APP_DB_MAX_CONNS for the field DB.MaxConns with prefix
+// APP. Values are parsed with the Optional's UnmarshalText.
+func Env(prefix string) Source {
+	return env{prefix: prefix}
+}
+
+func (e env) Name() string { return "env" }
+
+func (e env) Load(dst any) error {
+	v := reflect.ValueOf(dst).Elem()
+	var errs []error
+	eachField(v.Type(), "", func(index []int, path string, f reflect.StructField) {
+		name := f.Tag.Get("env")
+		if name == "" {
+			name = snake(path)
+			if e.prefix != "" {
+				name = e.prefix + "_" + name
+			}
+		}
+		if s, ok := os.LookupEnv(name); ok {
+			errs = append(errs, unmarshal(v.FieldByIndex(index), name, s))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+type flags struct {
+	set *flag.FlagSet
+}
+
+// Flags returns a Source reading the flags of set that were set on the
+// command line; set must already be parsed. A field is read from the flag
+// named by its flag tag or, by default, from its path in lower kebab case,
+// as in db-max-conns for the field DB.MaxConns. Values are parsed with the
+// Optional's UnmarshalText.
+func Flags(set *flag.FlagSet) Source {
+	return flags{set: set}
+}
+
+func (flags) Name() string { return "flags" }
+
+func (fl flags) Load(dst any) error {
+	given := map[string]string{}
+	fl.set.Visit(func(f *flag.Flag) { given[f.Name] = f.Value.String() })
+	v := reflect.ValueOf(dst).Elem()
+	var errs []error
+	eachField(v.Type(), "", func(index []int, path string, f reflect.StructField) {
+		name := f.Tag.Get("flag")
+		if name == "" {
+			name = strings.ToLower(strings.ReplaceAll(snake(path), "_", "-"))
+		}
+		if s, ok := given[name]; ok {
+			errs = append(errs, unmarshal(v.FieldByIndex(index), name, s))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+func unmarshal(field reflect.Value, name, s string) error {
+	if err := field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// snake converts a dotted Go field path to upper snake case.
+func snake(path string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(path, ".") {
+		if b.Len() > 0 {
+			b.WriteByte('_')
+		}
+		runes := []rune(part)
+		for i, r := range runes {
+			if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return b.String()
+}
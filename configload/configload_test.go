@@ -0,0 +1,106 @@
+package configload
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+type dbConfig struct {
+	MaxConns optional.Optional[int]
+	URL      optional.Optional[string] `env:"DATABASE_URL"`
+}
+
+type config struct {
+	Host    optional.Optional[string]        `json:"host"`
+	Port    optional.Optional[int]           `json:"port" flag:"listen-port"`
+	Timeout optional.Optional[time.Duration] `json:"timeout"`
+	Debug   optional.Optional[bool]          `json:"debug"`
+	DB      dbConfig                         `json:"db"`
+	Name    string
+}
+
+func TestLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(file, []byte(`{"host": "example.com", "port": 80, "db": {"MaxConns": 5}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("APP_PORT", "8080")
+	t.Setenv("APP_DB_MAX_CONNS", "10")
+	t.Setenv("DATABASE_URL", "postgres://db")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("listen-port", "", "")
+	fs.String("timeout", "", "")
+	fs.String("debug", "", "")
+	if err := fs.Parse([]string{"-listen-port=9090", "-timeout=5s"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg config
+	prov, err := Load(&cfg,
+		Defaults(config{Host: optional.Of("localhost"), Timeout: optional.Of(time.Second)}),
+		JSONFile(file),
+		JSONFile(filepath.Join(t.TempDir(), "missing.json")),
+		Env("APP"),
+		Flags(fs),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Host.Get() != "example.com" || cfg.Port.Get() != 9090 || cfg.Timeout.Get() != 5*time.Second {
+		t.Errorf("Expected example.com:9090 and 5s, but got %v:%v and %v", cfg.Host, cfg.Port, cfg.Timeout)
+	}
+	if cfg.DB.MaxConns.Get() != 10 || cfg.DB.URL.Get() != "postgres://db" || cfg.Debug.IsPresent() {
+		t.Errorf("Expected 10 conns, postgres://db and no debug, but got %+v %v", cfg.DB, cfg.Debug)
+	}
+	want := Provenance{
+		"Host":        file,
+		"Port":        "flags",
+		"Timeout":     "flags",
+		"DB.MaxConns": "env",
+		"DB.URL":      "env",
+	}
+	if !reflect.DeepEqual(prov, want) {
+		t.Errorf("Expected %v, but got %v", want, prov)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	var cfg config
+	if _, err := Load(cfg); err == nil {
+		t.Error("Expected an error for a non-pointer destination, but got nil")
+	}
+	if _, err := Load(&cfg, Defaults(dbConfig{})); err == nil {
+		t.Error("Expected an error for mismatched defaults, but got nil")
+	}
+	t.Setenv("APP_PORT", "eighty")
+	if _, err := Load(&cfg, Env("APP")); err == nil {
+		t.Error("Expected an error for an invalid value, but got nil")
+	}
+	file := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(file, []byte(`{`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(&cfg, JSONFile(file)); err == nil {
+		t.Error("Expected an error for invalid JSON, but got nil")
+	}
+}
+
+func TestSnake(t *testing.T) {
+	for in, want := range map[string]string{
+		"Port":        "PORT",
+		"DB.MaxConns": "DB_MAX_CONNS",
+		"HTTPServer":  "HTTP_SERVER",
+		"APIKey":      "API_KEY",
+	} {
+		if got := snake(in); got != want {
+			t.Errorf("Expected %s for %s, but got %s", want, in, got)
+		}
+	}
+}
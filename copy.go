@@ -0,0 +1,116 @@
+package optional
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CopyOf creates an Optional containing a deep copy of value, so that its
+// contents cannot be changed through slices, maps or pointers shared with
+// the caller, which makes it safe to cache or read concurrently. Like Of,
+// it panics if value is a nil pointer.
+//
+// Slices, maps, arrays, pointers, interfaces, nested Optionals and the
+// exported fields of structs are copied recursively, preserving shared and
+// cyclic pointers. Values of types registered with RegisterCopier are
+// copied with the registered function instead. Unexported struct fields,
+// channels and functions are copied as they are.
+func CopyOf[T any](value T) Optional[T] {
+	if isNil(value) {
+		panic("Optional.CopyOf: value cannot be nil")
+	}
+	c := deepCopy(reflect.ValueOf(&value).Elem(), map[copied]reflect.Value{}).Interface().(T)
+	return Optional[T]{value: &c}
+}
+
+// copied identifies a pointer already copied by deepCopy. The type tells a
+// struct apart from its first field.
+type copied struct {
+	t reflect.Type
+	p uintptr
+}
+
+// deepCopy returns a copy of v sharing no memory with it, except through
+// unexported struct fields. seen maps the pointers already copied to their
+// copies.
+func deepCopy(v reflect.Value, seen map[copied]reflect.Value) reflect.Value {
+	t := v.Type()
+	if f, ok := copiers.Load(t); ok {
+		return reflect.ValueOf(f).Call([]reflect.Value{v})[0]
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := copied{t, v.Pointer()}
+		if c, ok := seen[key]; ok {
+			return c
+		}
+		c := reflect.New(t.Elem())
+		seen[key] = c
+		c.Elem().Set(deepCopy(v.Elem(), seen))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(t, v.Len(), v.Cap())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(t, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value(), seen))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(t).Elem()
+		c.Set(deepCopy(v.Elem(), seen))
+		return c
+	case reflect.Struct:
+		c := reflect.New(t).Elem()
+		c.Set(v)
+		// The contents of an Optional are unexported, and types embedding
+		// one, such as String, may have other fields to copy as well.
+		if isOptionalType(t) {
+			if value, ok := optionalValue(v); ok {
+				setOptional(c, deepCopy(value, seen))
+			}
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				c.Field(i).Set(deepCopy(v.Field(i), seen))
+			}
+		}
+		return c
+	}
+	return v
+}
+
+// CopyPresent copies every present Optional field of the struct src, or of
+// the struct src points to, into the matching field of the struct dst points
+// to, like ApplyPatch. Unlike ApplyPatch, it skips src fields that are plain
+// values or have no match in dst, which suits mapping between API DTOs and
+// domain models that only partly overlap.
+func CopyPresent(dst, src any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return fmt.Errorf("optional: CopyPresent destination must be a non-nil pointer, got %T", dst)
+	}
+	return (&patcher{lenient: true}).apply(dv.Elem(), reflect.ValueOf(src), "")
+}
@@ -0,0 +1,137 @@
+package optional
+
+import "testing"
+
+type copyDTO struct {
+	Name    Optional[string] `json:"name"`
+	Email   Optional[string] `json:"email"`
+	Age     Optional[int32]  `json:"age"`
+	Token   Optional[string] `json:"token"`
+	Comment string           `json:"comment"`
+	Address Optional[copyAddressDTO]
+}
+
+type copyAddressDTO struct {
+	City Optional[string]
+}
+
+type copyModel struct {
+	Name     string
+	Mail     Optional[string] `json:"email"`
+	Age      *int32
+	Comment  string
+	Verified bool
+	Address  struct{ City string }
+}
+
+func TestCopyPresent(t *testing.T) {
+	m := copyModel{Name: "Alice", Comment: "keep", Verified: true}
+	dto := copyDTO{
+		Email:   Of("alice@example.com"),
+		Age:     Of(int32(30)),
+		Token:   Of("secret"),
+		Comment: "ignored",
+		Address: Of(copyAddressDTO{City: Of("Berlin")}),
+	}
+	if err := CopyPresent(&m, dto); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if m.Name != "Alice" || m.Comment != "keep" || !m.Verified {
+		t.Errorf("Expected untouched fields to be kept, but got %+v", m)
+	}
+	if m.Mail.OrElse("") != "alice@example.com" || m.Age == nil || *m.Age != 30 || m.Address.City != "Berlin" {
+		t.Errorf("Expected present fields to be copied, but got %+v", m)
+	}
+}
+
+func TestCopyPresentErrors(t *testing.T) {
+	if err := CopyPresent(copyModel{}, copyDTO{}); err == nil {
+		t.Error("Expected an error for a non-pointer destination, but got nil")
+	}
+	var m struct{ Name int }
+	if err := CopyPresent(&m, copyDTO{Name: Of("Alice")}); err == nil {
+		t.Error("Expected an error for mismatched types, but got nil")
+	}
+}
+
+type copyNode struct {
+	Tags     []string
+	Attrs    map[string][]int
+	Next     *copyNode
+	Any      any
+	Nickname Optional[[]byte]
+	secret   []byte
+}
+
+// copyTagged embeds an Optional next to a field of its own.
+type copyTagged struct {
+	Optional[[]string]
+	Labels []string
+}
+
+type copyBuffer struct {
+	data []byte
+}
+
+func TestCopyOf(t *testing.T) {
+	n := &copyNode{
+		Tags:     []string{"a"},
+		Attrs:    map[string][]int{"x": {1}},
+		Any:      []int{1},
+		Nickname: Of([]byte("bob")),
+		secret:   []byte("s"),
+	}
+	n.Next = n
+	o := CopyOf(n)
+
+	n.Tags[0] = "changed"
+	n.Attrs["x"][0] = 2
+	n.Any.([]int)[0] = 2
+	n.Nickname.Get()[0] = 'B'
+	c := o.Get()
+	if c == n || c.Tags[0] != "a" || c.Attrs["x"][0] != 1 || c.Any.([]int)[0] != 1 || string(c.Nickname.Get()) != "bob" {
+		t.Errorf("Expected the copy to be unaffected by changes to the original, but got %+v", c)
+	}
+	if c.Next != c {
+		t.Errorf("Expected the cycle to be preserved in the copy, but it was not")
+	}
+	if &c.secret[0] != &n.secret[0] {
+		t.Errorf("Expected unexported fields to be copied as they are, but they were not")
+	}
+}
+
+func TestCopyOfEmbedded(t *testing.T) {
+	tagged := copyTagged{Optional: Of([]string{"a"}), Labels: []string{"l"}}
+	o := CopyOf(tagged)
+	tagged.Get()[0] = "changed"
+	tagged.Labels[0] = "changed"
+	if c := o.Get(); c.Get()[0] != "a" || c.Labels[0] != "l" {
+		t.Errorf("Expected the embedded Optional and the other fields to be copied, but got %+v", c)
+	}
+
+	name := String{Optional: Of("Ada")}
+	if c := CopyOf(name).Get(); c.OrZero() != "Ada" {
+		t.Errorf("Expected Optional[Ada], but got %v", c)
+	}
+}
+
+func TestCopyOfCopier(t *testing.T) {
+	RegisterCopier(func(b copyBuffer) copyBuffer {
+		return copyBuffer{data: append([]byte(nil), b.data...)}
+	})
+	b := []copyBuffer{{data: []byte("abc")}}
+	o := CopyOf(b)
+	b[0].data[0] = 'x'
+	if got := string(o.Get()[0].data); got != "abc" {
+		t.Errorf("Expected the registered copier to be used, but got %s", got)
+	}
+}
+
+func TestCopyOfNil(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected CopyOf to panic for a nil pointer")
+		}
+	}()
+	CopyOf[*copyNode](nil)
+}
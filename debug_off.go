@@ -0,0 +1,14 @@
+//go:build !optionaldebug
+
+package optional
+
+// origin is empty unless the package is built with the optionaldebug tag.
+type origin struct{}
+
+func captureOrigin() origin {
+	return origin{}
+}
+
+func (origin) describe() string {
+	return ""
+}
@@ -0,0 +1,42 @@
+//go:build optionaldebug
+
+package optional
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// maxOriginDepth bounds the number of frames recorded for each empty Optional.
+const maxOriginDepth = 32
+
+// origin records the stack that created an empty Optional, so that a failing
+// Get can report where the empty value came from.
+type origin struct {
+	pcs *[]uintptr
+}
+
+func captureOrigin() origin {
+	pcs := make([]uintptr, maxOriginDepth)
+	// Skip runtime.Callers, captureOrigin and the constructor itself.
+	pcs = pcs[:runtime.Callers(3, pcs)]
+	return origin{pcs: &pcs}
+}
+
+func (o origin) describe() string {
+	if o.pcs == nil {
+		return " (zero value, no creation site recorded)"
+	}
+	var b strings.Builder
+	b.WriteString("\nempty Optional created at:")
+	frames := runtime.CallersFrames(*o.pcs)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,35 @@
+//go:build optionaldebug
+
+package optional
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func emptyFromHelper() Optional[int] {
+	return Empty[int]()
+}
+
+func TestOptionalGetReportsOrigin(t *testing.T) {
+	defer func() {
+		r := recover()
+		msg := fmt.Sprint(r)
+		if !strings.Contains(msg, "emptyFromHelper") || !strings.Contains(msg, "debug_test.go") {
+			t.Errorf("Expected panic message to name the creation site, but got %v", r)
+		}
+	}()
+	emptyFromHelper().Get()
+}
+
+func TestOptionalGetZeroValueOrigin(t *testing.T) {
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "zero value") {
+			t.Errorf("Expected panic message to mention the zero value, but got %q", msg)
+		}
+	}()
+	var zero Optional[int]
+	zero.Get()
+}
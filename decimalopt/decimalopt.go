@@ -0,0 +1,44 @@
+// Package decimalopt converts between Optional[decimal.Decimal] and
+// decimal.NullDecimal from github.com/shopspring/decimal.
+//
+// The functions accept any struct with the fields of decimal.NullDecimal,
+// a Decimal and a Valid flag, so the package does not import the library
+// itself:
+//
+//	price := decimalopt.FromNullDecimal(row.Price)
+//	row.Price = decimalopt.ToNullDecimal[decimal.NullDecimal](price)
+//
+// Optional[decimal.Decimal] can also be scanned from and written to nullable
+// database columns directly, as Optional passes Scan and Value through to
+// decimal.Decimal.
+package decimalopt
+
+import "github.com/hermann-craft/optional"
+
+// NullDecimal is the shape of decimal.NullDecimal holding a D.
+type NullDecimal[D any] interface {
+	~struct {
+		Decimal D
+		Valid   bool
+	}
+}
+
+// FromNullDecimal converts a decimal.NullDecimal into an Optional.
+func FromNullDecimal[N NullDecimal[D], D any](n N) optional.Optional[D] {
+	d := struct {
+		Decimal D
+		Valid   bool
+	}(n)
+	if !d.Valid {
+		return optional.Empty[D]()
+	}
+	return optional.Of(d.Decimal)
+}
+
+// ToNullDecimal converts an Optional into a decimal.NullDecimal.
+func ToNullDecimal[N NullDecimal[D], D any](o optional.Optional[D]) N {
+	if o.IsEmpty() {
+		return N{}
+	}
+	return N{Decimal: o.Get(), Valid: true}
+}
@@ -0,0 +1,65 @@
+package decimalopt
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+// decimal mirrors decimal.Decimal, storing hundredths.
+type decimal struct {
+	cents int64
+}
+
+func (d *decimal) Scan(src any) error {
+	var units, cents int64
+	if _, err := fmt.Sscanf(fmt.Sprint(src), "%d.%d", &units, &cents); err != nil {
+		return err
+	}
+	d.cents = units*100 + cents
+	return nil
+}
+
+func (d decimal) Value() (driver.Value, error) {
+	return fmt.Sprintf("%d.%02d", d.cents/100, d.cents%100), nil
+}
+
+// nullDecimal mirrors decimal.NullDecimal.
+type nullDecimal struct {
+	Decimal decimal
+	Valid   bool
+}
+
+func TestConversions(t *testing.T) {
+	price := decimal{cents: 1999}
+	if got := FromNullDecimal(nullDecimal{Decimal: price, Valid: true}); got.OrZero() != price {
+		t.Errorf("Expected Optional[19.99], but got %v", got)
+	}
+	if got := FromNullDecimal(nullDecimal{}); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	if got := ToNullDecimal[nullDecimal](optional.Of(price)); !got.Valid || got.Decimal != price {
+		t.Errorf("Expected a valid 19.99, but got %v", got)
+	}
+	if got := ToNullDecimal[nullDecimal](optional.Empty[decimal]()); got.Valid {
+		t.Errorf("Expected an invalid NullDecimal, but got %v", got)
+	}
+}
+
+func TestScanValue(t *testing.T) {
+	var o optional.Optional[decimal]
+	if err := o.Scan("12.50"); err != nil || o.OrZero().cents != 1250 {
+		t.Errorf("Expected Optional[12.50], but got %v (%v)", o, err)
+	}
+	if v, err := o.Value(); err != nil || v != "12.50" {
+		t.Errorf("Expected 12.50, but got %#v (%v)", v, err)
+	}
+	if err := o.Scan(nil); err != nil || o.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v (%v)", o, err)
+	}
+	if v, err := o.Value(); err != nil || v != nil {
+		t.Errorf("Expected nil, but got %#v (%v)", v, err)
+	}
+}
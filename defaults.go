@@ -0,0 +1,131 @@
+package optional
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// FillDefaults sets every empty Optional field of the struct target points
+// to from the matching present field of the defaults struct, or, failing
+// that, from the field's default tag:
+//
+//	type Config struct {
+//		Host    optional.Optional[string]        `default:"localhost"`
+//		Timeout optional.Optional[time.Duration] `default:"30s"`
+//	}
+//
+// Fields of defaults are matched by name or json tag and may be plain or
+// Optional; defaults may be nil to use the tags only. Nested structs are
+// filled recursively. Present fields are never changed.
+func FillDefaults(target any, defaults any) error {
+	dst := reflect.ValueOf(target)
+	if dst.Kind() != reflect.Pointer || dst.IsNil() || dst.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("optional: FillDefaults target must be a non-nil pointer to a struct, got %T", target)
+	}
+	src := indirect(reflect.ValueOf(defaults))
+	if src.IsValid() && src.Kind() != reflect.Struct {
+		return fmt.Errorf("optional: FillDefaults defaults must be a struct, got %T", defaults)
+	}
+	return fillDefaults(dst.Elem(), src, "")
+}
+
+func fillDefaults(dst, src reflect.Value, path string) error {
+	var errs []error
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		df := dst.Field(i)
+		var sf reflect.Value
+		if src.IsValid() {
+			sf, _ = matchingField(src, f)
+		}
+		switch {
+		case isOptionalType(f.Type):
+			if _, present := optionalValue(df); present {
+				continue
+			}
+			elem := optionalElem(f.Type)
+			if sf.IsValid() {
+				if v, ok := fieldValue(sf); ok {
+					cv, ok := convertTo(v, elem)
+					if !ok {
+						errs = append(errs, fmt.Errorf("optional: cannot use default %s for field %s of type %s", v.Type(), path+f.Name, elem))
+						continue
+					}
+					setOptional(df, cv)
+					continue
+				}
+			}
+			tag, ok := f.Tag.Lookup("default")
+			if !ok {
+				continue
+			}
+			v, err := parseDefault(tag, elem)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("optional: invalid default for field %s: %w", path+f.Name, err))
+				continue
+			}
+			setOptional(df, v)
+		case f.Type.Kind() == reflect.Struct:
+			if sf.IsValid() && sf.Kind() != reflect.Struct {
+				sf = reflect.Value{}
+			}
+			errs = append(errs, fillDefaults(df, sf, path+f.Name+"."))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// parseDefault parses the default tag value s as a value of type t.
+func parseDefault(s string, t reflect.Type) (reflect.Value, error) {
+	v := reflect.New(t)
+	if u, ok := v.Interface().(encoding.TextUnmarshaler); ok {
+		return v.Elem(), u.UnmarshalText([]byte(s))
+	}
+	v = v.Elem()
+	if t == durationType {
+		d, err := time.ParseDuration(s)
+		v.SetInt(int64(d))
+		return v, err
+	}
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 0, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 0, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetFloat(n)
+	default:
+		return v, fmt.Errorf("unsupported type %s", t)
+	}
+	return v, nil
+}
@@ -0,0 +1,81 @@
+package optional
+
+import (
+	"testing"
+	"time"
+)
+
+type defaultsTLS struct {
+	Port Optional[int] `default:"443"`
+}
+
+type defaultsConfig struct {
+	Host    Optional[string]        `default:"localhost"`
+	Port    Optional[uint16]        `default:"8080"`
+	Debug   Optional[bool]          `default:"true"`
+	Ratio   Optional[float64]       `default:"0.5"`
+	Timeout Optional[time.Duration] `default:"30s"`
+	Start   Optional[time.Time]     `default:"2024-01-02T03:04:05Z"`
+	Name    Optional[string]
+	TLS     defaultsTLS
+}
+
+func TestFillDefaultsTags(t *testing.T) {
+	config := defaultsConfig{Host: Of("example.com")}
+	if err := FillDefaults(&config, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.Host.Get() != "example.com" {
+		t.Errorf("Expected present fields to be kept, but got %v", config.Host)
+	}
+	if config.Port.Get() != 8080 || !config.Debug.Get() || config.Ratio.Get() != 0.5 || config.Timeout.Get() != 30*time.Second {
+		t.Errorf("Expected tag defaults, but got %+v", config)
+	}
+	if !config.Start.Get().Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("Expected 2024-01-02T03:04:05Z, but got %v", config.Start)
+	}
+	if config.Name.IsPresent() {
+		t.Errorf("Expected Name to stay empty, but got %v", config.Name)
+	}
+	if config.TLS.Port.Get() != 443 {
+		t.Errorf("Expected nested default 443, but got %v", config.TLS.Port)
+	}
+}
+
+func TestFillDefaultsStruct(t *testing.T) {
+	defaults := struct {
+		Host Optional[string]
+		Name string `json:"Name"`
+		Port uint16
+		TLS  struct{ Port int }
+	}{Host: Of("db.local"), Name: "app", Port: 5432}
+	defaults.TLS.Port = 8443
+
+	var config defaultsConfig
+	if err := FillDefaults(&config, &defaults); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.Host.Get() != "db.local" || config.Name.Get() != "app" || config.TLS.Port.Get() != 8443 {
+		t.Errorf("Expected defaults from the struct, but got %+v", config)
+	}
+	if config.Debug.Get() != true {
+		t.Errorf("Expected tags to fill the remaining fields, but got %v", config.Debug)
+	}
+}
+
+func TestFillDefaultsErrors(t *testing.T) {
+	if err := FillDefaults(defaultsConfig{}, nil); err == nil {
+		t.Error("Expected an error for a non-pointer target, but got nil")
+	}
+	var bad struct {
+		Port Optional[int]      `default:"eighty"`
+		Tags Optional[[]string] `default:"a,b"`
+	}
+	if err := FillDefaults(&bad, nil); err == nil {
+		t.Error("Expected an error for invalid defaults, but got nil")
+	}
+	var config defaultsConfig
+	if err := FillDefaults(&config, struct{ Host int }{1}); err == nil {
+		t.Error("Expected an error for a mismatched default, but got nil")
+	}
+}
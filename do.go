@@ -0,0 +1,55 @@
+package optional
+
+// Do runs a sequence of steps that each produce an Optional, stopping at the
+// first empty one, which reads better than nested FlatMap calls crossing
+// several types:
+//
+//	var d optional.Do[Order]
+//	user := optional.Step(&d, func() optional.Optional[User] { return findUser(id) })
+//	addr := optional.Step(&d, func() optional.Optional[Address] { return user.Address })
+//	d.Return(func() Order { return Order{User: user, Address: addr} })
+//	return d.Result()
+//
+// Once a step is empty, later steps and Return are skipped and Result is
+// empty. The zero Do is ready to use.
+type Do[T any] struct {
+	stopped bool
+	result  Optional[T]
+}
+
+// Step runs fn unless d has stopped and returns the value it produced. If fn
+// returns an empty Optional, d stops. Step returns the zero value of U when
+// d has stopped.
+func Step[T, U any](d *Do[T], fn func() Optional[U]) U {
+	var zero U
+	if d.stopped {
+		return zero
+	}
+	u := fn()
+	if u.IsEmpty() {
+		d.stopped = true
+		return zero
+	}
+	return u.Get()
+}
+
+// Return computes the result with fn unless d has stopped.
+func (d *Do[T]) Return(fn func() T) {
+	if !d.stopped {
+		d.result = Of(fn())
+	}
+}
+
+// Stopped reports whether a step was empty.
+func (d *Do[T]) Stopped() bool {
+	return d.stopped
+}
+
+// Result returns the value computed by Return, or an empty Optional if a
+// step was empty or Return was not called.
+func (d *Do[T]) Result() Optional[T] {
+	if d.stopped {
+		return Empty[T]()
+	}
+	return d.result
+}
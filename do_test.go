@@ -0,0 +1,43 @@
+package optional
+
+import (
+	"strconv"
+	"testing"
+)
+
+func parse(s string) Optional[int] {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return Empty[int]()
+	}
+	return Of(n)
+}
+
+func TestDo(t *testing.T) {
+	var d Do[string]
+	a := Step(&d, func() Optional[int] { return parse("2") })
+	b := Step(&d, func() Optional[float64] { return Of(float64(a) * 1.5) })
+	d.Return(func() string { return strconv.FormatFloat(b, 'f', 1, 64) })
+	if got := d.Result(); got.OrElse("") != "3.0" || d.Stopped() {
+		t.Errorf("Expected Optional[3.0], but got %v", got)
+	}
+}
+
+func TestDoStops(t *testing.T) {
+	var d Do[int]
+	calls := 0
+	a := Step(&d, func() Optional[int] { return parse("x") })
+	b := Step(&d, func() Optional[int] { calls++; return Of(a + 1) })
+	d.Return(func() int { calls++; return b })
+	if got := d.Result(); got.IsPresent() || !d.Stopped() || calls != 0 {
+		t.Errorf("Expected an empty result without further calls, but got %v after %d calls", got, calls)
+	}
+}
+
+func TestDoWithoutReturn(t *testing.T) {
+	var d Do[int]
+	Step(&d, func() Optional[int] { return Of(1) })
+	if got := d.Result(); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
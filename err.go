@@ -0,0 +1,20 @@
+package optional
+
+// OfErr creates an Optional holding err, or an empty Optional if err is nil.
+// Of, by contrast, stores a nil error interface as a present value, which is
+// rarely what is meant; the optnilof analyzer of optcheck reports such calls.
+func OfErr(err error) Optional[error] {
+	if err == nil {
+		return Empty[error]()
+	}
+	return Of(err)
+}
+
+// ErrOrNil returns the error held by o, or nil if o is empty, so that an
+// optional error can be returned as a plain one.
+func ErrOrNil(o Optional[error]) error {
+	if o.IsEmpty() {
+		return nil
+	}
+	return *o.value
+}
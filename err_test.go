@@ -0,0 +1,26 @@
+package optional
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOfErr(t *testing.T) {
+	if o := OfErr(nil); o.IsPresent() {
+		t.Errorf("Expected an empty Optional for a nil error, but got %v", o)
+	}
+	errFailed := errors.New("failed")
+	if o := OfErr(errFailed); !o.IsPresent() || o.Get() != errFailed {
+		t.Errorf("Expected Optional[failed], but got %v", o)
+	}
+}
+
+func TestErrOrNil(t *testing.T) {
+	if err := ErrOrNil(Empty[error]()); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	errFailed := errors.New("failed")
+	if err := ErrOrNil(OfErr(errFailed)); err != errFailed {
+		t.Errorf("Expected %v, but got %v", errFailed, err)
+	}
+}
@@ -0,0 +1,50 @@
+package optional
+
+// Filter returns the Optional if its value matches the predicate, otherwise
+// an empty Optional.
+func (o Optional[T]) Filter(pred func(T) bool) Optional[T] {
+	if o.value == nil || !pred(*o.value) {
+		return Empty[T]()
+	}
+	return o
+}
+
+// FilterErr is like Filter, but the predicate may explain a rejection. A
+// non-nil error from the predicate is returned together with an empty
+// Optional, whatever the predicate's verdict.
+func (o Optional[T]) FilterErr(pred func(T) (bool, error)) (Optional[T], error) {
+	if o.value == nil {
+		return o, nil
+	}
+	ok, err := pred(*o.value)
+	if err != nil || !ok {
+		return Empty[T](), err
+	}
+	return o, nil
+}
+
+// FilterOrErr is like Filter, but returns err when a present value does not
+// match the predicate. An empty Optional yields no error.
+func (o Optional[T]) FilterOrErr(pred func(T) bool, err error) (Optional[T], error) {
+	if o.value == nil {
+		return o, nil
+	}
+	if !pred(*o.value) {
+		return Empty[T](), err
+	}
+	return o, nil
+}
+
+// Normalize applies fns in order to the value if present and returns an
+// Optional holding the result. The normalize package provides common
+// functions for strings.
+func (o Optional[T]) Normalize(fns ...func(T) T) Optional[T] {
+	if o.value == nil {
+		return o
+	}
+	v := *o.value
+	for _, fn := range fns {
+		v = fn(v)
+	}
+	return Optional[T]{value: &v}
+}
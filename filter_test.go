@@ -0,0 +1,69 @@
+package optional
+
+import (
+	"errors"
+	"testing"
+)
+
+func isEven(n int) bool { return n%2 == 0 }
+
+var errOdd = errors.New("odd")
+
+func TestFilter(t *testing.T) {
+	if got := Of(4).Filter(isEven); got.OrElse(0) != 4 {
+		t.Errorf("Expected Optional[4], but got %v", got)
+	}
+	if got := Of(3).Filter(isEven); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	if got := Empty[int]().Filter(isEven); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestFilterErr(t *testing.T) {
+	pred := func(n int) (bool, error) {
+		if n < 0 {
+			return false, errors.New("negative")
+		}
+		return isEven(n), nil
+	}
+	if got, err := Of(4).FilterErr(pred); err != nil || got.OrElse(0) != 4 {
+		t.Errorf("Expected Optional[4], but got %v (%v)", got, err)
+	}
+	if got, err := Of(3).FilterErr(pred); err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional and no error, but got %v (%v)", got, err)
+	}
+	if got, err := Of(-2).FilterErr(pred); err == nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional and an error, but got %v (%v)", got, err)
+	}
+	if got, err := Empty[int]().FilterErr(pred); err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional and no error, but got %v (%v)", got, err)
+	}
+}
+
+func TestFilterOrErr(t *testing.T) {
+	if got, err := Of(4).FilterOrErr(isEven, errOdd); err != nil || got.OrElse(0) != 4 {
+		t.Errorf("Expected Optional[4], but got %v (%v)", got, err)
+	}
+	if got, err := Of(3).FilterOrErr(isEven, errOdd); !errors.Is(err, errOdd) || got.IsPresent() {
+		t.Errorf("Expected an empty Optional and %v, but got %v (%v)", errOdd, got, err)
+	}
+	if got, err := Empty[int]().FilterOrErr(isEven, errOdd); err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional and no error, but got %v (%v)", got, err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	double := func(n int) int { return n * 2 }
+	inc := func(n int) int { return n + 1 }
+	if got := Of(3).Normalize(double, inc); got.OrElse(0) != 7 {
+		t.Errorf("Expected Optional[7], but got %v", got)
+	}
+	if got := Of(3).Normalize(); got.OrElse(0) != 3 {
+		t.Errorf("Expected Optional[3], but got %v", got)
+	}
+	if got := Empty[int]().Normalize(double); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
@@ -1,3 +1,13 @@
 module github.com/hermann-craft/optional
 
-go 1.23.2
+go 1.24.0
+
+require (
+	golang.org/x/sync v0.16.0
+	golang.org/x/tools v0.36.0
+)
+
+require (
+	github.com/google/go-cmp v0.7.0 // indirect
+	golang.org/x/mod v0.27.0 // indirect
+)
@@ -0,0 +1,49 @@
+// Package gqlopt converts between optional.Nullable and graphql.Omittable
+// from github.com/99designs/gqlgen, so GraphQL mutation inputs keep the
+// difference between a field that was not provided and one explicitly set
+// to null when they flow into patch structs.
+//
+// gqlgen represents a nullable input field as an Omittable holding a
+// pointer: an unset Omittable means the field was omitted and a nil pointer
+// means null. The package relies only on the ValueOK method of Omittable,
+// so it does not import gqlgen itself:
+//
+//	patch := ArticlePatch{Title: gqlopt.FromOmittable(input.Title)}
+//	input.Title = gqlopt.ToOmittable(patch.Title, graphql.OmittableOf[*string])
+package gqlopt
+
+import "github.com/hermann-craft/optional"
+
+// Omittable is the method set of graphql.Omittable[*T].
+type Omittable[T any] interface {
+	ValueOK() (*T, bool)
+}
+
+// FromOmittable converts an Omittable holding a *T into a Nullable: unset
+// if the field was omitted, null if it was set to null, and holding the
+// value otherwise.
+func FromOmittable[T any, O Omittable[T]](o O) optional.Nullable[T] {
+	v, ok := o.ValueOK()
+	switch {
+	case !ok:
+		return optional.Unset[T]()
+	case v == nil:
+		return optional.Null[T]()
+	}
+	return optional.NullableOf(*v)
+}
+
+// ToOmittable converts a Nullable into an Omittable using gqlgen's
+// constructor for it, graphql.OmittableOf[*T]. An unset Nullable yields the
+// zero value of O, which gqlgen treats as omitted.
+func ToOmittable[O, T any](n optional.Nullable[T], of func(*T) O) O {
+	switch {
+	case !n.IsSet():
+		var zero O
+		return zero
+	case n.IsNull():
+		return of(nil)
+	}
+	v := n.Optional().Get()
+	return of(&v)
+}
@@ -0,0 +1,50 @@
+package gqlopt
+
+import (
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+// omittable mirrors graphql.Omittable from gqlgen.
+type omittable[T any] struct {
+	value T
+	set   bool
+}
+
+func omittableOf[T any](v T) omittable[T] {
+	return omittable[T]{value: v, set: true}
+}
+
+func (o omittable[T]) ValueOK() (T, bool) {
+	return o.value, o.set
+}
+
+func TestFromOmittable(t *testing.T) {
+	title := "Hello"
+	if n := FromOmittable[string](omittable[*string]{}); n.IsSet() {
+		t.Errorf("Expected an unset Nullable, but got %v", n)
+	}
+	if n := FromOmittable[string](omittableOf[*string](nil)); !n.IsNull() {
+		t.Errorf("Expected a null Nullable, but got %v", n)
+	}
+	if n := FromOmittable[string](omittableOf(&title)); n.Optional().OrElse("") != "Hello" {
+		t.Errorf("Expected a Nullable holding Hello, but got %v", n)
+	}
+}
+
+func TestToOmittable(t *testing.T) {
+	if o := ToOmittable(optional.Unset[string](), omittableOf[*string]); o.set {
+		t.Errorf("Expected an unset Omittable, but got %+v", o)
+	}
+	if o := ToOmittable(optional.Null[string](), omittableOf[*string]); !o.set || o.value != nil {
+		t.Errorf("Expected a null Omittable, but got %+v", o)
+	}
+	o := ToOmittable(optional.NullableOf("Hello"), omittableOf[*string])
+	if !o.set || o.value == nil || *o.value != "Hello" {
+		t.Errorf("Expected an Omittable holding Hello, but got %+v", o)
+	}
+	if n := FromOmittable[string](o); n.Optional().OrElse("") != "Hello" {
+		t.Errorf("Expected the round trip to keep Hello, but got %v", n)
+	}
+}
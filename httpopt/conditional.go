@@ -0,0 +1,92 @@
+package httpopt
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+// IfNoneMatch returns the entity tags listed in the If-None-Match header of
+// r, such as `"abc"`, `W/"abc"` or `*`, or an empty Optional if r has no
+// such header.
+func IfNoneMatch(r *http.Request) optional.Optional[[]string] {
+	var etags []string
+	for _, line := range r.Header.Values("If-None-Match") {
+		for _, etag := range strings.Split(line, ",") {
+			if etag = strings.TrimSpace(etag); etag != "" {
+				etags = append(etags, etag)
+			}
+		}
+	}
+	if len(etags) == 0 {
+		return optional.Empty[[]string]()
+	}
+	return optional.Of(etags)
+}
+
+// IfModifiedSince returns the time in the If-Modified-Since header of r, or
+// an empty Optional if r has no valid one.
+func IfModifiedSince(r *http.Request) optional.Optional[time.Time] {
+	t, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return optional.Empty[time.Time]()
+	}
+	return optional.Of(t)
+}
+
+// ByteRange is one range of a Range header. Start is empty for a suffix
+// range such as "-500", the last 500 bytes, and End is empty for an open
+// range such as "500-".
+type ByteRange struct {
+	Start optional.Optional[int64]
+	End   optional.Optional[int64]
+}
+
+// Bounds returns the offsets of the first and last bytes of the range in a
+// representation of size bytes, or false if the range cannot be satisfied.
+func (b ByteRange) Bounds(size int64) (first, last int64, ok bool) {
+	if b.Start.IsEmpty() {
+		n := min(b.End.OrZero(), size)
+		return size - n, size - 1, n > 0
+	}
+	first = b.Start.Get()
+	last = min(b.End.OrElse(size-1), size-1)
+	return first, last, first < size
+}
+
+// Range returns the byte ranges requested by the Range header of r, or an
+// empty Optional if r has no such header or it is malformed, in which case
+// the header is to be ignored.
+func Range(r *http.Request) optional.Optional[[]ByteRange] {
+	spec, ok := strings.CutPrefix(r.Header.Get("Range"), "bytes=")
+	if !ok {
+		return optional.Empty[[]ByteRange]()
+	}
+	var ranges []ByteRange
+	for _, part := range strings.Split(spec, ",") {
+		start, end, ok := strings.Cut(strings.TrimSpace(part), "-")
+		if !ok {
+			return optional.Empty[[]ByteRange]()
+		}
+		b := ByteRange{Start: parseOffset(start), End: parseOffset(end)}
+		switch {
+		case b.Start.IsEmpty() && (start != "" || b.End.IsEmpty()),
+			b.End.IsEmpty() && end != "",
+			b.Start.IsPresent() && b.End.IsPresent() && b.End.Get() < b.Start.Get():
+			return optional.Empty[[]ByteRange]()
+		}
+		ranges = append(ranges, b)
+	}
+	return optional.Of(ranges)
+}
+
+func parseOffset(s string) optional.Optional[int64] {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return optional.Empty[int64]()
+	}
+	return optional.Of(n)
+}
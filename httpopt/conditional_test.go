@@ -0,0 +1,62 @@
+package httpopt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+func requestWithHeader(key, value string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if value != "" {
+		r.Header.Set(key, value)
+	}
+	return r
+}
+
+func TestIfNoneMatch(t *testing.T) {
+	got := IfNoneMatch(requestWithHeader("If-None-Match", `"abc", W/"def"`))
+	if want := []string{`"abc"`, `W/"def"`}; !slices.Equal(got.OrZero(), want) {
+		t.Errorf("Expected %v, but got %v", want, got)
+	}
+	if got := IfNoneMatch(requestWithHeader("If-None-Match", "")); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestIfModifiedSince(t *testing.T) {
+	want := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	got := IfModifiedSince(requestWithHeader("If-Modified-Since", want.Format(http.TimeFormat)))
+	if !got.IsPresent() || !got.Get().Equal(want) {
+		t.Errorf("Expected %v, but got %v", want, got)
+	}
+	if got := IfModifiedSince(requestWithHeader("If-Modified-Since", "yesterday")); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestRange(t *testing.T) {
+	got := Range(requestWithHeader("Range", "bytes=0-499, 500-, -200"))
+	if !got.IsPresent() || len(got.Get()) != 3 {
+		t.Fatalf("Expected 3 ranges, but got %v", got)
+	}
+	want := [][2]int64{{0, 499}, {500, 999}, {800, 999}}
+	for i, b := range got.Get() {
+		first, last, ok := b.Bounds(1000)
+		if !ok || first != want[i][0] || last != want[i][1] {
+			t.Errorf("Expected range %d to be %v, but got %d-%d (%v)", i, want[i], first, last, ok)
+		}
+	}
+	if _, _, ok := (ByteRange{Start: optional.Of[int64](2000)}).Bounds(1000); ok {
+		t.Errorf("Expected a range past the end to be unsatisfiable")
+	}
+	for _, header := range []string{"", "items=0-1", "bytes=5-1", "bytes=a-b", "bytes=-", "bytes=1"} {
+		if got := Range(requestWithHeader("Range", header)); got.IsPresent() {
+			t.Errorf("Expected an empty Optional for %q, but got %v", header, got)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package httpopt
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hermann-craft/optional"
+)
+
+// ErrInvalidPage is wrapped by the errors ParsePage returns for malformed or
+// out-of-range pagination parameters.
+var ErrInvalidPage = errors.New("httpopt: invalid page")
+
+// MaxPageLimit is the largest limit ParsePage accepts.
+var MaxPageLimit = 1000
+
+// Page holds the pagination parameters of a list request. Offset and Cursor
+// are alternatives: a request gives at most one of them.
+type Page struct {
+	Limit  optional.Optional[int]    `query:"limit"`
+	Offset optional.Optional[int]    `query:"offset"`
+	Cursor optional.Optional[string] `query:"cursor"`
+	Sort   optional.Optional[string] `query:"sort"`
+}
+
+// ParsePage reads the limit, offset, cursor and sort query parameters of r,
+// taking the value of defaults for each one that is absent or blank. The
+// limit must be between 1 and MaxPageLimit and the offset must not be
+// negative.
+//
+//	page, err := httpopt.ParsePage(r, httpopt.Page{Limit: optional.Of(50)})
+//	if err != nil {
+//		http.Error(w, err.Error(), http.StatusBadRequest)
+//		return
+//	}
+func ParsePage(r *http.Request, defaults Page) (Page, error) {
+	q := r.URL.Query()
+	page := defaults
+	var errs []error
+	if limit, err := queryInt(q, "limit"); err != nil {
+		errs = append(errs, err)
+	} else if limit.IsPresent() {
+		page.Limit = limit
+	}
+	if offset, err := queryInt(q, "offset"); err != nil {
+		errs = append(errs, err)
+	} else if offset.IsPresent() {
+		page.Offset, page.Cursor = offset, optional.Empty[string]()
+	}
+	if cursor := queryString(q, "cursor"); cursor.IsPresent() {
+		if q.Get("offset") != "" {
+			errs = append(errs, fmt.Errorf("%w: offset and cursor cannot be combined", ErrInvalidPage))
+		}
+		page.Cursor, page.Offset = cursor, optional.Empty[int]()
+	}
+	if sort := queryString(q, "sort"); sort.IsPresent() {
+		page.Sort = sort
+	}
+	if page.Limit.Filter(func(n int) bool { return n < 1 || n > MaxPageLimit }).IsPresent() {
+		errs = append(errs, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidPage, MaxPageLimit))
+	}
+	if page.Offset.Filter(func(n int) bool { return n < 0 }).IsPresent() {
+		errs = append(errs, fmt.Errorf("%w: offset must not be negative", ErrInvalidPage))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return Page{}, err
+	}
+	return page, nil
+}
+
+// Query encodes the page as query parameters, leaving out the empty ones,
+// for building links to other pages.
+func (p Page) Query() url.Values {
+	return EncodeQuery(p)
+}
+
+func queryString(q url.Values, key string) optional.Optional[string] {
+	return optional.Of(q.Get(key)).Filter(func(s string) bool { return s != "" })
+}
+
+func queryInt(q url.Values, key string) (optional.Optional[int], error) {
+	s := queryString(q, key)
+	if s.IsEmpty() {
+		return optional.Empty[int](), nil
+	}
+	n, err := strconv.Atoi(s.Get())
+	if err != nil {
+		return optional.Empty[int](), fmt.Errorf("%w: %s must be an integer", ErrInvalidPage, key)
+	}
+	return optional.Of(n), nil
+}
@@ -0,0 +1,50 @@
+package httpopt
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+var pageDefaults = Page{Limit: optional.Of(50), Sort: optional.Of("-created")}
+
+func TestParsePage(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Page
+	}{
+		{"", pageDefaults},
+		{"limit=10&offset=20", Page{Limit: optional.Of(10), Offset: optional.Of(20), Sort: optional.Of("-created")}},
+		{"cursor=abc&sort=name&limit=", Page{Limit: optional.Of(50), Cursor: optional.Of("abc"), Sort: optional.Of("name")}},
+	}
+	for _, tt := range tests {
+		got, err := ParsePage(httptest.NewRequest(http.MethodGet, "/items?"+tt.query, nil), pageDefaults)
+		if err != nil {
+			t.Errorf("Expected no error for %q, but got %v", tt.query, err)
+			continue
+		}
+		if !optional.Equal(got.Limit, tt.want.Limit) || !optional.Equal(got.Offset, tt.want.Offset) ||
+			!optional.Equal(got.Cursor, tt.want.Cursor) || !optional.Equal(got.Sort, tt.want.Sort) {
+			t.Errorf("Expected %+v for %q, but got %+v", tt.want, tt.query, got)
+		}
+	}
+}
+
+func TestParsePageInvalid(t *testing.T) {
+	for _, query := range []string{"limit=0", "limit=5000", "limit=ten", "offset=-1", "offset=10&cursor=abc"} {
+		_, err := ParsePage(httptest.NewRequest(http.MethodGet, "/items?"+query, nil), pageDefaults)
+		if !errors.Is(err, ErrInvalidPage) {
+			t.Errorf("Expected ErrInvalidPage for %q, but got %v", query, err)
+		}
+	}
+}
+
+func TestPageQuery(t *testing.T) {
+	p := Page{Limit: optional.Of(10), Cursor: optional.Of("abc")}
+	if got := p.Query().Encode(); got != "cursor=abc&limit=10" {
+		t.Errorf("Expected cursor=abc&limit=10, but got %s", got)
+	}
+}
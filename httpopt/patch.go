@@ -0,0 +1,107 @@
+// Package httpopt provides net/http helpers built on the optional package.
+package httpopt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/hermann-craft/optional"
+)
+
+// MergePatchContentType is the media type of JSON merge patches (RFC 7396).
+const MergePatchContentType = "application/merge-patch+json"
+
+// ErrInvalidPatch is wrapped by the errors returned for request bodies that
+// cannot be decoded or fail validation.
+var ErrInvalidPatch = errors.New("httpopt: invalid patch")
+
+// Validator is implemented by patch types that check their own contents.
+type Validator interface {
+	Validate() error
+}
+
+// Patch decodes the body of r as a JSON merge patch into a P, validates it
+// and applies it to target with optional.ApplyPatchChanges, returning the
+// paths of the fields that changed.
+//
+// P normally consists of optional.Nullable fields, so that keys absent from
+// the body leave target untouched and keys set to null clear it. If P
+// implements Validator, the patch is validated before it is applied.
+func Patch[T, P any](r *http.Request, target *T) ([]string, error) {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mediaType != MergePatchContentType && mediaType != "application/json") {
+			return nil, fmt.Errorf("%w: unsupported content type %q", ErrInvalidPatch, ct)
+		}
+	}
+	var patch P
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&patch); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+	if v, ok := any(&patch).(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+		}
+	}
+	return optional.ApplyPatchChanges(target, patch)
+}
+
+// PatchHandler returns a handler running the complete PATCH workflow: it
+// loads the entity with load, responding 404 Not Found when it is empty,
+// applies the request body to it with Patch, saves it with save when any
+// field changed and responds with the entity encoded as JSON.
+//
+// Invalid patches are answered with 400 Bad Request and the reason. Errors
+// from load or save are answered with a bare 500 Internal Server Error, so
+// that their details do not reach the client, and passed to onError, or
+// logged with slog if onError is nil.
+func PatchHandler[T, P any](
+	load func(r *http.Request) (optional.Optional[T], error),
+	save func(ctx context.Context, entity T, changed []string) error,
+	onError func(r *http.Request, err error),
+) http.Handler {
+	if onError == nil {
+		onError = func(r *http.Request, err error) {
+			slog.ErrorContext(r.Context(), "httpopt: patch failed", "method", r.Method, "path", r.URL.Path, "error", err)
+		}
+	}
+	internalError := func(w http.ResponseWriter, r *http.Request, err error) {
+		onError(r, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loaded, err := load(r)
+		if err != nil {
+			internalError(w, r, err)
+			return
+		}
+		if loaded.IsEmpty() {
+			http.NotFound(w, r)
+			return
+		}
+		entity := loaded.Get()
+		changed, err := Patch[T, P](r, &entity)
+		switch {
+		case errors.Is(err, ErrInvalidPatch):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case err != nil:
+			internalError(w, r, err)
+			return
+		}
+		if len(changed) > 0 {
+			if err := save(r.Context(), entity, changed); err != nil {
+				internalError(w, r, err)
+				return
+			}
+		}
+		writeJSON(w, http.StatusOK, "application/json", entity)
+	})
+}
@@ -0,0 +1,153 @@
+package httpopt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+type article struct {
+	Title    string                    `json:"title"`
+	Subtitle optional.Optional[string] `json:"subtitle"`
+	Views    int                       `json:"views"`
+}
+
+type articlePatch struct {
+	Title    optional.Nullable[string] `json:"title"`
+	Subtitle optional.Nullable[string] `json:"subtitle"`
+}
+
+func (p *articlePatch) Validate() error {
+	if p.Title.IsNull() {
+		return errors.New("title cannot be null")
+	}
+	return nil
+}
+
+func newPatchRequest(body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPatch, "/articles/1", strings.NewReader(body))
+	r.Header.Set("Content-Type", MergePatchContentType)
+	return r
+}
+
+func TestPatch(t *testing.T) {
+	a := article{Title: "Draft", Subtitle: optional.Of("old"), Views: 10}
+	changed, err := Patch[article, articlePatch](newPatchRequest(`{"title":"Final","subtitle":null}`), &a)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if a.Title != "Final" || a.Subtitle.IsPresent() || a.Views != 10 {
+		t.Errorf("Expected title Final, empty subtitle and 10 views, but got %+v", a)
+	}
+	if want := []string{"Title", "Subtitle"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("Expected changed fields %v, but got %v", want, changed)
+	}
+}
+
+func TestPatchAbsentKeys(t *testing.T) {
+	a := article{Title: "Draft", Subtitle: optional.Of("old")}
+	changed, err := Patch[article, articlePatch](newPatchRequest(`{"title":"Draft"}`), &a)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(changed) != 0 || a.Subtitle.OrElse("") != "old" {
+		t.Errorf("Expected no change, but got %v and %+v", changed, a)
+	}
+}
+
+func TestPatchInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *http.Request
+	}{
+		{"malformed", newPatchRequest(`{"title":`)},
+		{"unknown field", newPatchRequest(`{"views":3}`)},
+		{"validation", newPatchRequest(`{"title":null}`)},
+		{"content type", func() *http.Request {
+			r := newPatchRequest(`{}`)
+			r.Header.Set("Content-Type", "text/plain")
+			return r
+		}()},
+	}
+	for _, tt := range tests {
+		var a article
+		if _, err := Patch[article, articlePatch](tt.req, &a); !errors.Is(err, ErrInvalidPatch) {
+			t.Errorf("%s: expected ErrInvalidPatch, but got %v", tt.name, err)
+		}
+	}
+}
+
+func TestPatchHandler(t *testing.T) {
+	stored := map[string]article{"1": {Title: "Draft"}}
+	var saved []string
+	h := PatchHandler[article, articlePatch](
+		func(r *http.Request) (optional.Optional[article], error) {
+			a, ok := stored[strings.TrimPrefix(r.URL.Path, "/articles/")]
+			if !ok {
+				return optional.Empty[article](), nil
+			}
+			return optional.Of(a), nil
+		},
+		func(ctx context.Context, a article, changed []string) error {
+			stored["1"] = a
+			saved = changed
+			return nil
+		},
+		nil,
+	)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, newPatchRequest(`{"subtitle":"New"}`))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, but got %d: %s", rec.Code, rec.Body)
+	}
+	if want := `{"title":"Draft","subtitle":"New","views":0}`; strings.TrimSpace(rec.Body.String()) != want {
+		t.Errorf("Expected body %s, but got %s", want, rec.Body)
+	}
+	if stored["1"].Subtitle.OrElse("") != "New" || !reflect.DeepEqual(saved, []string{"Subtitle"}) {
+		t.Errorf("Expected entity to be saved with changed Subtitle, but got %+v and %v", stored["1"], saved)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/articles/2", strings.NewReader(`{}`)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for missing entity, but got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, newPatchRequest(`not json`))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid patch, but got %d", rec.Code)
+	}
+}
+
+func TestPatchHandlerInternalError(t *testing.T) {
+	var reported error
+	h := PatchHandler[article, articlePatch](
+		func(r *http.Request) (optional.Optional[article], error) {
+			return optional.Of(article{Title: "Draft"}), nil
+		},
+		func(ctx context.Context, a article, changed []string) error {
+			return errors.New("db: connection refused to 10.0.0.7")
+		},
+		func(r *http.Request, err error) { reported = err },
+	)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, newPatchRequest(`{"subtitle":"New"}`))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status 500, but got %d", rec.Code)
+	}
+	if body := strings.TrimSpace(rec.Body.String()); body != http.StatusText(http.StatusInternalServerError) {
+		t.Errorf("Expected a generic body, but got %q", body)
+	}
+	if reported == nil || !strings.Contains(reported.Error(), "connection refused") {
+		t.Errorf("Expected the save error to be reported, but got %v", reported)
+	}
+}
@@ -0,0 +1,91 @@
+package httpopt
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/hermann-craft/optional"
+)
+
+// principalKey is the context key under which Authenticate stores the
+// principal of type U.
+type principalKey[U any] struct{}
+
+// Authenticate returns middleware that calls extract once per request and
+// makes the principal it returns available to handlers with CurrentUser. An
+// empty principal marks an anonymous request, which is passed on; an error,
+// such as an invalid or expired token, is answered with 401 Unauthorized.
+//
+//	mux := http.NewServeMux()
+//	handler := httpopt.Authenticate(func(r *http.Request) (optional.Optional[User], error) {
+//		token := httpopt.BearerToken(r)
+//		if token.IsEmpty() {
+//			return optional.Empty[User](), nil
+//		}
+//		return sessions.Lookup(r.Context(), token.Get())
+//	})(mux)
+func Authenticate[U any](extract func(r *http.Request) (optional.Optional[U], error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, err := extract(r)
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			if user.IsPresent() {
+				r = r.WithContext(WithUser(r.Context(), user.Get()))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireUser returns middleware that answers 401 Unauthorized unless the
+// request carries a principal of type U, as stored by Authenticate.
+func RequireUser[U any](next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if CurrentUser[U](r.Context()).IsEmpty() {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WithUser returns a copy of ctx carrying user as the principal of type U.
+func WithUser[U any](ctx context.Context, user U) context.Context {
+	return context.WithValue(ctx, principalKey[U]{}, user)
+}
+
+// CurrentUser returns the principal of type U carried by ctx, or an empty
+// Optional for anonymous requests and for a nil principal, such as a nil
+// *User stored with WithUser.
+func CurrentUser[U any](ctx context.Context) optional.Optional[U] {
+	user, ok := ctx.Value(principalKey[U]{}).(U)
+	if !ok {
+		return optional.Empty[U]()
+	}
+	return optional.OfNilable(user)
+}
+
+// BearerToken returns the token of an "Authorization: Bearer" header, if r
+// has one.
+func BearerToken(r *http.Request) optional.Optional[string] {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	token = strings.TrimSpace(token)
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return optional.Empty[string]()
+	}
+	return optional.Of(token)
+}
+
+// CookieValue returns the value of the named cookie, if r has a non-empty
+// one.
+func CookieValue(r *http.Request, name string) optional.Optional[string] {
+	c, err := r.Cookie(name)
+	if err != nil || c.Value == "" {
+		return optional.Empty[string]()
+	}
+	return optional.Of(c.Value)
+}
@@ -0,0 +1,109 @@
+package httpopt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+type user struct {
+	Name string
+}
+
+func extractUser(r *http.Request) (optional.Optional[user], error) {
+	token := BearerToken(r)
+	switch {
+	case token.IsEmpty():
+		return optional.Empty[user](), nil
+	case token.Get() == "expired":
+		return optional.Empty[user](), errors.New("token expired")
+	}
+	return optional.Of(user{Name: token.Get()}), nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	var got optional.Optional[user]
+	handler := Authenticate(extractUser)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = CurrentUser[user](r.Context())
+	}))
+
+	tests := []struct {
+		authorization string
+		status        int
+		want          optional.Optional[user]
+	}{
+		{"Bearer ada", http.StatusOK, optional.Of(user{Name: "ada"})},
+		{"", http.StatusOK, optional.Empty[user]()},
+		{"Bearer expired", http.StatusUnauthorized, optional.Empty[user]()},
+	}
+	for _, tt := range tests {
+		got = optional.Empty[user]()
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.authorization != "" {
+			r.Header.Set("Authorization", tt.authorization)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != tt.status || !optional.Equal(got, tt.want) {
+			t.Errorf("Expected %d and %v for %q, but got %d and %v", tt.status, tt.want, tt.authorization, w.Code, got)
+		}
+	}
+}
+
+func TestRequireUser(t *testing.T) {
+	handler := RequireUser[user](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401, but got %d", w.Code)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r = r.WithContext(WithUser(r.Context(), user{Name: "ada"}))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200, but got %d", w.Code)
+	}
+}
+
+func TestCurrentUserByType(t *testing.T) {
+	ctx := WithUser(context.Background(), "service-account")
+	if got := CurrentUser[user](ctx); got.IsPresent() {
+		t.Errorf("Expected no user of another type, but got %v", got)
+	}
+	if got := CurrentUser[string](ctx); got.OrElse("") != "service-account" {
+		t.Errorf("Expected Optional[service-account], but got %v", got)
+	}
+}
+
+func TestCurrentUserNil(t *testing.T) {
+	ctx := WithUser[*user](context.Background(), nil)
+	if got := CurrentUser[*user](ctx); got.IsPresent() {
+		t.Errorf("Expected an empty Optional for a nil user, but got %v", got)
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "bearer abc")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+	if got := BearerToken(r); got.OrElse("") != "abc" {
+		t.Errorf("Expected Optional[abc], but got %v", got)
+	}
+	if got := CookieValue(r, "session"); got.OrElse("") != "s1" {
+		t.Errorf("Expected Optional[s1], but got %v", got)
+	}
+	if got := CookieValue(r, "missing"); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	r.Header.Set("Authorization", "Basic abc")
+	if got := BearerToken(r); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
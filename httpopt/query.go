@@ -0,0 +1,111 @@
+package httpopt
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hermann-craft/optional/reflectopt"
+)
+
+// EncodeQuery encodes the exported fields of the struct v, or of the struct
+// it points to, as query parameters, leaving out empty Optionals and nil
+// pointers so that only the filters that were given are sent:
+//
+//	type ListFilter struct {
+//		Status optional.Optional[string]    `query:"status"`
+//		Since  optional.Optional[time.Time] `query:"since" layout:"2006-01-02"`
+//		Tags   []string                     `query:"tag"`
+//		Limit  int                          `query:"limit"`
+//	}
+//
+//	u.RawQuery = httpopt.EncodeQuery(filter).Encode()
+//
+// A parameter is named by the query tag of its field, or else by its json
+// tag or its name; fields tagged "-" are skipped. Slices add one value per
+// element. Times are formatted with the layout tag, RFC 3339 by default;
+// values implementing encoding.TextMarshaler are encoded with it and other
+// values with fmt.
+func EncodeQuery(v any) url.Values {
+	values := url.Values{}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return values
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return values
+	}
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := queryName(f)
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		fv, ok := queryValue(rv.Field(i))
+		if !ok {
+			continue
+		}
+		layout := f.Tag.Get("layout")
+		if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+			for j := 0; j < fv.Len(); j++ {
+				values.Add(name, formatQuery(fv.Index(j), layout))
+			}
+			continue
+		}
+		values.Add(name, formatQuery(fv, layout))
+	}
+	return values
+}
+
+// queryName returns the parameter name of the field f.
+func queryName(f reflect.StructField) string {
+	for _, key := range []string{"query", "json"} {
+		if tag, ok := f.Tag.Lookup(key); ok {
+			if name, _, _ := strings.Cut(tag, ","); name != "" {
+				return name
+			}
+		}
+	}
+	return f.Name
+}
+
+// queryValue returns the value held by v, which may be a plain value, a
+// pointer or an Optional, and whether there is one.
+func queryValue(v reflect.Value) (reflect.Value, bool) {
+	if reflectopt.IsOptionalType(v.Type()) {
+		inner, ok := reflectopt.ValueOf(v)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		v = inner
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+func formatQuery(v reflect.Value, layout string) string {
+	switch x := v.Interface().(type) {
+	case time.Time:
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		return x.Format(layout)
+	case encoding.TextMarshaler:
+		if text, err := x.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
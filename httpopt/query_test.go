@@ -0,0 +1,53 @@
+package httpopt
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+type listFilter struct {
+	Status  optional.Optional[string]    `query:"status"`
+	Since   optional.Optional[time.Time] `query:"since" layout:"2006-01-02"`
+	Until   optional.Optional[time.Time] `query:"until"`
+	Tags    optional.Optional[[]string]  `query:"tag"`
+	Owner   *string                      `json:"owner,omitempty"`
+	Limit   int
+	Ignored string `query:"-"`
+}
+
+func TestEncodeQuery(t *testing.T) {
+	owner := "ada"
+	f := listFilter{
+		Status:  optional.Of("open"),
+		Since:   optional.Of(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)),
+		Tags:    optional.Of([]string{"go", "api"}),
+		Owner:   &owner,
+		Limit:   20,
+		Ignored: "x",
+	}
+	want := url.Values{
+		"status": {"open"},
+		"since":  {"2024-05-01"},
+		"tag":    {"go", "api"},
+		"owner":  {"ada"},
+		"Limit":  {"20"},
+	}
+	if got := EncodeQuery(&f); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, but got %v", want, got)
+	}
+}
+
+func TestEncodeQueryEmpty(t *testing.T) {
+	got := EncodeQuery(listFilter{Until: optional.Of(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))})
+	want := url.Values{"until": {"2024-05-01T12:00:00Z"}, "Limit": {"0"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, but got %v", want, got)
+	}
+	if got := EncodeQuery((*listFilter)(nil)); len(got) != 0 {
+		t.Errorf("Expected no values for a nil struct, but got %v", got)
+	}
+}
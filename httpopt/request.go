@@ -0,0 +1,102 @@
+package httpopt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/hermann-craft/optional"
+)
+
+// RequestBuilder builds an outbound request, adding query parameters,
+// headers and body fields only when they hold a value:
+//
+//	req, err := httpopt.NewRequest(http.MethodPatch, "https://api.example.com/users/1").
+//		QueryIfPresent("dry_run", dryRun).
+//		HeaderIfPresent("If-Match", etag).
+//		JSONBody(patch).
+//		Build(ctx)
+type RequestBuilder struct {
+	method string
+	url    string
+	query  url.Values
+	header http.Header
+	body   optional.Optional[any]
+}
+
+// NewRequest returns a RequestBuilder for the given method and URL. Query
+// parameters already in the URL are kept.
+func NewRequest(method, rawURL string) *RequestBuilder {
+	return &RequestBuilder{method: method, url: rawURL, query: url.Values{}, header: http.Header{}}
+}
+
+// Query adds the query parameter key with the given value.
+func (b *RequestBuilder) Query(key, value string) *RequestBuilder {
+	b.query.Add(key, value)
+	return b
+}
+
+// QueryIfPresent adds the query parameter key if value is present.
+func (b *RequestBuilder) QueryIfPresent(key string, value optional.Optional[string]) *RequestBuilder {
+	value.IfPresent(func(v string) { b.Query(key, v) })
+	return b
+}
+
+// Header sets the header key to the given value.
+func (b *RequestBuilder) Header(key, value string) *RequestBuilder {
+	b.header.Set(key, value)
+	return b
+}
+
+// HeaderIfPresent sets the header key if value is present.
+func (b *RequestBuilder) HeaderIfPresent(key string, value optional.Optional[string]) *RequestBuilder {
+	value.IfPresent(func(v string) { b.Header(key, v) })
+	return b
+}
+
+// JSONBody sets the body of the request to v encoded as JSON. Fields of v
+// that are unset Nullables, or empty Optionals tagged omitzero, are left out
+// of the encoding, so a patch struct sends only the fields that were set.
+// A nil v, such as a nil pointer to a patch struct, leaves the body empty.
+func (b *RequestBuilder) JSONBody(v any) *RequestBuilder {
+	b.body = optional.OfNilable(v)
+	return b
+}
+
+// Build returns the request, or an error if the URL is invalid or the body
+// cannot be encoded.
+func (b *RequestBuilder) Build(ctx context.Context) (*http.Request, error) {
+	u, err := url.Parse(b.url)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.query) > 0 {
+		q := u.Query()
+		for key, values := range b.query {
+			q[key] = append(q[key], values...)
+		}
+		u.RawQuery = q.Encode()
+	}
+	var body io.Reader
+	if b.body.IsPresent() {
+		data, err := json.Marshal(b.body.Get())
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, b.method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range b.header {
+		req.Header[key] = values
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
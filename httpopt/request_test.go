@@ -0,0 +1,65 @@
+package httpopt
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+func TestRequestBuilder(t *testing.T) {
+	type userPatch struct {
+		Name  optional.Nullable[string] `json:"name,omitzero"`
+		Email optional.Nullable[string] `json:"email,omitzero"`
+		Phone optional.Optional[string] `json:"phone,omitzero"`
+	}
+	req, err := NewRequest(http.MethodPatch, "https://api.example.com/users/1?v=2").
+		QueryIfPresent("dry_run", optional.Of("true")).
+		QueryIfPresent("trace", optional.Empty[string]()).
+		HeaderIfPresent("If-Match", optional.Of(`"abc"`)).
+		HeaderIfPresent("X-Request-Id", optional.Empty[string]()).
+		JSONBody(userPatch{Name: optional.NullableOf("Ada"), Email: optional.Null[string]()}).
+		Build(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if got := req.URL.String(); got != "https://api.example.com/users/1?dry_run=true&v=2" {
+		t.Errorf("Expected only present query parameters, but got %s", got)
+	}
+	if req.Header.Get("If-Match") != `"abc"` || req.Header.Get("X-Request-Id") != "" {
+		t.Errorf("Expected only present headers, but got %v", req.Header)
+	}
+	if req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Expected a JSON content type, but got %q", req.Header.Get("Content-Type"))
+	}
+	body, _ := io.ReadAll(req.Body)
+	if string(body) != `{"name":"Ada","email":null}` {
+		t.Errorf("Expected only set fields in the body, but got %s", body)
+	}
+}
+
+func TestRequestBuilderWithoutBody(t *testing.T) {
+	req, err := NewRequest(http.MethodGet, "https://api.example.com/users").Query("q", "ada").Build(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if req.Body != nil || req.Header.Get("Content-Type") != "" || req.URL.RawQuery != "q=ada" {
+		t.Errorf("Expected a GET request without body, but got %v %v %q", req.Body, req.Header, req.URL.RawQuery)
+	}
+	if _, err := NewRequest(http.MethodGet, "://bad").Build(context.Background()); err == nil {
+		t.Errorf("Expected an error for an invalid URL")
+	}
+}
+
+func TestRequestBuilderNilBody(t *testing.T) {
+	var patch *articlePatch
+	req, err := NewRequest(http.MethodPatch, "https://api.example.com/users/1").JSONBody(patch).Build(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if req.Body != nil || req.Header.Get("Content-Type") != "" {
+		t.Errorf("Expected no body for a nil pointer, but got %v with %v", req.Body, req.Header)
+	}
+}
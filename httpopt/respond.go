@@ -0,0 +1,61 @@
+package httpopt
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hermann-craft/optional"
+)
+
+// EmptyResponse describes the response written by RespondOptionalOr for an
+// empty Optional.
+type EmptyResponse struct {
+	// Status is the status code, such as http.StatusNotFound.
+	Status int
+	// Body is encoded as JSON if not nil, such as a problem details object
+	// (RFC 9457). A nil Body responds with the status text as plain text.
+	Body any
+	// ContentType defaults to "application/json" when Body is set.
+	ContentType string
+}
+
+// NotFound is the EmptyResponse used by RespondOptional.
+var NotFound = EmptyResponse{Status: http.StatusNotFound}
+
+// RespondOptional writes the value of opt encoded as JSON with the given
+// status if it is present, and 404 Not Found otherwise. It replaces the
+// branch every repository-backed GET handler repeats:
+//
+//	user, err := repo.Find(r.Context(), r.PathValue("id"))
+//	if err != nil {
+//		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+//		return
+//	}
+//	httpopt.RespondOptional(w, user, http.StatusOK)
+func RespondOptional[T any](w http.ResponseWriter, opt optional.Optional[T], status int) {
+	RespondOptionalOr(w, opt, status, NotFound)
+}
+
+// RespondOptionalOr is like RespondOptional but writes empty when opt is
+// empty.
+func RespondOptionalOr[T any](w http.ResponseWriter, opt optional.Optional[T], status int, empty EmptyResponse) {
+	if opt.IsPresent() {
+		writeJSON(w, status, "application/json", opt.Get())
+		return
+	}
+	if empty.Body == nil {
+		http.Error(w, http.StatusText(empty.Status), empty.Status)
+		return
+	}
+	contentType := empty.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	writeJSON(w, empty.Status, contentType, empty.Body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, contentType string, v any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,47 @@
+package httpopt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+func TestRespondOptional(t *testing.T) {
+	w := httptest.NewRecorder()
+	RespondOptional(w, optional.Of(article{Title: "Hello"}), http.StatusOK)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Expected 200 with JSON, but got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	if body := strings.TrimSpace(w.Body.String()); body != `{"title":"Hello","subtitle":null,"views":0}` {
+		t.Errorf("Expected the encoded article, but got %s", body)
+	}
+
+	w = httptest.NewRecorder()
+	RespondOptional(w, optional.Empty[article](), http.StatusOK)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, but got %d", w.Code)
+	}
+}
+
+func TestRespondOptionalOr(t *testing.T) {
+	type problem struct {
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+	}
+	empty := EmptyResponse{
+		Status:      http.StatusGone,
+		Body:        problem{Title: "Article deleted", Status: http.StatusGone},
+		ContentType: "application/problem+json",
+	}
+	w := httptest.NewRecorder()
+	RespondOptionalOr(w, optional.Empty[article](), http.StatusOK, empty)
+	if w.Code != http.StatusGone || w.Header().Get("Content-Type") != "application/problem+json" {
+		t.Errorf("Expected 410 with a problem body, but got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	if body := strings.TrimSpace(w.Body.String()); body != `{"title":"Article deleted","status":410}` {
+		t.Errorf("Expected the problem body, but got %s", body)
+	}
+}
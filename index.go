@@ -0,0 +1,35 @@
+package optional
+
+// Index returns s[i], or an empty Optional if i is out of range. A nil
+// element is returned as a present nil.
+func Index[T any](s []T, i int) Optional[T] {
+	if i < 0 || i >= len(s) {
+		return Empty[T]()
+	}
+	v := s[i]
+	return Optional[T]{value: &v}
+}
+
+// ByteAt returns the byte s[i], or an empty Optional if i is out of range.
+func ByteAt(s string, i int) Optional[byte] {
+	if i < 0 || i >= len(s) {
+		return Empty[byte]()
+	}
+	return Of(s[i])
+}
+
+// RuneAt returns the i-th rune of s, counting from 0, or an empty Optional
+// if s has no such rune. Like []rune(s)[i], it takes time proportional to
+// i; invalid UTF-8 counts as utf8.RuneError.
+func RuneAt(s string, i int) Optional[rune] {
+	if i < 0 {
+		return Empty[rune]()
+	}
+	for _, r := range s {
+		if i == 0 {
+			return Of(r)
+		}
+		i--
+	}
+	return Empty[rune]()
+}
@@ -0,0 +1,46 @@
+package optional
+
+import "testing"
+
+func TestIndex(t *testing.T) {
+	s := []string{"a", "b"}
+	if got := Index(s, 1); got.OrElse("") != "b" {
+		t.Errorf("Expected Optional[b], but got %v", got)
+	}
+	for _, i := range []int{-1, 2} {
+		if got := Index(s, i); got.IsPresent() {
+			t.Errorf("Expected an empty Optional for %d, but got %v", i, got)
+		}
+	}
+	if got := Index[int](nil, 0); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	if got := Index([]*int{nil}, 0); !got.IsPresent() || got.Get() != nil {
+		t.Errorf("Expected a present nil, but got %v", got)
+	}
+}
+
+func TestByteAt(t *testing.T) {
+	if got := ByteAt("héllo", 1); got.OrElse(0) != 0xc3 {
+		t.Errorf("Expected Optional[195], but got %v", got)
+	}
+	for _, i := range []int{-1, 6} {
+		if got := ByteAt("héllo", i); got.IsPresent() {
+			t.Errorf("Expected an empty Optional for %d, but got %v", i, got)
+		}
+	}
+}
+
+func TestRuneAt(t *testing.T) {
+	if got := RuneAt("héllo", 1); got.OrElse(0) != 'é' {
+		t.Errorf("Expected Optional[é], but got %v", got)
+	}
+	if got := RuneAt("héllo", 4); got.OrElse(0) != 'o' {
+		t.Errorf("Expected Optional[o], but got %v", got)
+	}
+	for _, i := range []int{-1, 5} {
+		if got := RuneAt("héllo", i); got.IsPresent() {
+			t.Errorf("Expected an empty Optional for %d, but got %v", i, got)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package optional
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptionalMarshalJSON(t *testing.T) {
+	type payload struct {
+		Name  Optional[string] `json:"name"`
+		Age   Optional[int]    `json:"age"`
+		Email Optional[string] `json:"email,omitzero"`
+	}
+	data, err := json.Marshal(payload{Name: Of("Ada")})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if string(data) != `{"name":"Ada","age":null}` {
+		t.Errorf("Expected JSON with null age and no email, but got %s", data)
+	}
+}
+
+func TestOptionalUnmarshalJSON(t *testing.T) {
+	var v struct {
+		Name Optional[string]
+		Age  Optional[int]
+		Tags Optional[[]string]
+	}
+	if err := json.Unmarshal([]byte(`{"Name":"Ada","Age":null}`), &v); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if v.Name.OrElse("") != "Ada" || v.Age.IsPresent() || v.Tags.IsPresent() {
+		t.Errorf("Expected Name Ada and empty Age and Tags, but got %v, %v, %v", v.Name, v.Age, v.Tags)
+	}
+	if err := json.Unmarshal([]byte(`{"Age":"x"}`), &v); err == nil {
+		t.Errorf("Expected error for mismatched type, but got none")
+	}
+}
+
+func TestOptionalAppendJSON(t *testing.T) {
+	strs := []string{"", "plain", "quote\"back\\slash", "\n\r\t\b\f\x00\x1f", "<a href='x'>&</a>", "café    \U0001F600", "bad\xffutf8"}
+	for _, s := range strs {
+		want, _ := json.Marshal(s)
+		got, err := Of(s).AppendJSON([]byte("x"))
+		if err != nil || string(got) != "x"+string(want) {
+			t.Errorf("Expected x%s for %q, but got %s, %v", want, s, got, err)
+		}
+	}
+	for _, o := range []any{Of(true), Of(-42), Of(uint64(7)), Of(1.5), Of([]int{1}), Empty[int]()} {
+		m := o.(json.Marshaler)
+		want, _ := m.MarshalJSON()
+		got, err := o.(interface{ AppendJSON([]byte) ([]byte, error) }).AppendJSON(nil)
+		if err != nil || string(got) != string(want) {
+			t.Errorf("Expected %s, but got %s, %v", want, got, err)
+		}
+	}
+}
+
+func TestOptionalAppendJSONAllocs(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	s, n := Of("hello <world>"), Of(12345)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = s.AppendJSON(buf[:0])
+		buf, _ = n.AppendJSON(buf)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, but got %v", allocs)
+	}
+}
@@ -0,0 +1,122 @@
+// Package kafkaopt reads the key and headers of Kafka messages as
+// Optionals, since both are frequently absent:
+//
+//	key := kafkaopt.Key(record)
+//	trace := kafkaopt.HeaderString(record, "trace-id")
+//	meta, err := kafkaopt.JSONHeader[Meta](record, "meta")
+//
+// The functions take a *kgo.Record from github.com/twmb/franz-go or a
+// *sarama.ConsumerMessage from github.com/IBM/sarama. Both are structs with
+// a Key field holding the key, nil for a null key, and a Headers field
+// holding a slice of headers with Key and Value fields, which the package
+// accesses by reflection, so it imports neither client.
+package kafkaopt
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hermann-craft/optional"
+)
+
+// Key returns the key of msg, or an empty Optional if it is null. An empty
+// but non-null key is present. It panics if msg is not a Kafka message.
+func Key(msg any) optional.Optional[[]byte] {
+	key := field(msg, "Key")
+	if key.Kind() != reflect.Slice || key.Type().Elem().Kind() != reflect.Uint8 {
+		panic(fmt.Sprintf("kafkaopt: %T is not a Kafka message", msg))
+	}
+	if key.IsNil() {
+		return optional.Empty[[]byte]()
+	}
+	return optional.Of(key.Bytes())
+}
+
+// HeaderValue returns the value of the first header of msg named name, or
+// an empty Optional if there is none. It panics if msg is not a Kafka
+// message.
+func HeaderValue(msg any, name string) optional.Optional[[]byte] {
+	headers := field(msg, "Headers")
+	if headers.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("kafkaopt: %T is not a Kafka message", msg))
+	}
+	for i := 0; i < headers.Len(); i++ {
+		h := reflect.Indirect(headers.Index(i))
+		if !h.IsValid() {
+			continue
+		}
+		key, value := h.FieldByName("Key"), h.FieldByName("Value")
+		if !key.IsValid() || !value.IsValid() {
+			panic(fmt.Sprintf("kafkaopt: %s is not a Kafka header", h.Type()))
+		}
+		if keyString(key) == name {
+			return optional.Of(value.Bytes())
+		}
+	}
+	return optional.Empty[[]byte]()
+}
+
+// HeaderString is HeaderValue returning the value as a string.
+func HeaderString(msg any, name string) optional.Optional[string] {
+	return optional.Map(HeaderValue(msg, name), func(v []byte) string {
+		return string(v)
+	})
+}
+
+// DecodeKey returns the key of msg decoded with unmarshal, such as
+// json.Unmarshal or proto.Unmarshal wrapped to take an any, or an empty
+// Optional if it is null.
+func DecodeKey[T any](msg any, unmarshal func([]byte, any) error) (optional.Optional[T], error) {
+	return decode[T](Key(msg), unmarshal)
+}
+
+// DecodeHeader returns the value of the header of msg named name decoded
+// with unmarshal, or an empty Optional if there is no such header.
+func DecodeHeader[T any](msg any, name string, unmarshal func([]byte, any) error) (optional.Optional[T], error) {
+	return decode[T](HeaderValue(msg, name), unmarshal)
+}
+
+// JSONKey is DecodeKey with json.Unmarshal.
+func JSONKey[T any](msg any) (optional.Optional[T], error) {
+	return DecodeKey[T](msg, json.Unmarshal)
+}
+
+// JSONHeader is DecodeHeader with json.Unmarshal.
+func JSONHeader[T any](msg any, name string) (optional.Optional[T], error) {
+	return DecodeHeader[T](msg, name, json.Unmarshal)
+}
+
+func decode[T any](data optional.Optional[[]byte], unmarshal func([]byte, any) error) (optional.Optional[T], error) {
+	if data.IsEmpty() {
+		return optional.Empty[T](), nil
+	}
+	var v T
+	if err := unmarshal(data.Get(), &v); err != nil {
+		return optional.Empty[T](), err
+	}
+	return optional.Of(v), nil
+}
+
+// field returns the field of the message struct msg, or of the struct it
+// points to, named name.
+func field(msg any, name string) reflect.Value {
+	v := reflect.Indirect(reflect.ValueOf(msg))
+	if v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("kafkaopt: %T is not a Kafka message", msg))
+	}
+	f := v.FieldByName(name)
+	if !f.IsValid() {
+		panic(fmt.Sprintf("kafkaopt: %T is not a Kafka message", msg))
+	}
+	return f
+}
+
+// keyString returns the header key k, which is a string for franz-go and a
+// []byte for sarama.
+func keyString(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return k.String()
+	}
+	return string(k.Bytes())
+}
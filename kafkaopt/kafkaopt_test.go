@@ -0,0 +1,86 @@
+package kafkaopt
+
+import (
+	"bytes"
+	"testing"
+)
+
+// record mirrors kgo.Record.
+type record struct {
+	Key     []byte
+	Value   []byte
+	Headers []recordHeader
+}
+
+type recordHeader struct {
+	Key   string
+	Value []byte
+}
+
+// consumerMessage mirrors sarama.ConsumerMessage.
+type consumerMessage struct {
+	Headers []*saramaHeader
+	Key     []byte
+	Value   []byte
+}
+
+type saramaHeader struct {
+	Key   []byte
+	Value []byte
+}
+
+type meta struct {
+	Tenant string `json:"tenant"`
+}
+
+func TestKey(t *testing.T) {
+	if Key(&record{}).IsPresent() {
+		t.Errorf("Expected an empty key for a null key, but got a present one")
+	}
+	if k := Key(&record{Key: []byte{}}); k.IsEmpty() || len(k.Get()) != 0 {
+		t.Errorf("Expected a present empty key, but got %v", k)
+	}
+	if k := Key(consumerMessage{Key: []byte("user-1")}); !bytes.Equal(k.OrZero(), []byte("user-1")) {
+		t.Errorf("Expected user-1, but got %v", k)
+	}
+}
+
+func TestHeaderValue(t *testing.T) {
+	r := &record{Headers: []recordHeader{{Key: "trace-id", Value: []byte("abc")}, {Key: "trace-id", Value: []byte("def")}}}
+	if v := HeaderString(r, "trace-id"); v.OrZero() != "abc" {
+		t.Errorf("Expected the first header abc, but got %v", v)
+	}
+	if HeaderValue(r, "missing").IsPresent() {
+		t.Errorf("Expected an empty Optional for a missing header, but got a present one")
+	}
+
+	m := &consumerMessage{Headers: []*saramaHeader{nil, {Key: []byte("trace-id"), Value: []byte("xyz")}}}
+	if v := HeaderString(m, "trace-id"); v.OrZero() != "xyz" {
+		t.Errorf("Expected xyz, but got %v", v)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	r := &record{Key: []byte(`"user-1"`), Headers: []recordHeader{{Key: "meta", Value: []byte(`{"tenant":"acme"}`)}, {Key: "bad", Value: []byte(`{`)}}}
+	if k, err := JSONKey[string](r); err != nil || k.OrZero() != "user-1" {
+		t.Errorf("Expected user-1, but got %v, %v", k, err)
+	}
+	if m, err := JSONHeader[meta](r, "meta"); err != nil || m.OrZero().Tenant != "acme" {
+		t.Errorf("Expected tenant acme, but got %v, %v", m, err)
+	}
+	if m, err := JSONHeader[meta](r, "missing"); err != nil || m.IsPresent() {
+		t.Errorf("Expected an empty Optional without error, but got %v, %v", m, err)
+	}
+	if _, err := JSONHeader[meta](r, "bad"); err == nil {
+		t.Errorf("Expected an error for an invalid header, but got nil")
+	}
+}
+
+func TestInvalidMessage(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for a value that is not a message")
+		}
+	}()
+	Key("not a message")
+}
@@ -0,0 +1,87 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/hermann-craft/optional"
+)
+
+// File is a KV storing each value as a JSON file in a directory, named after
+// its escaped key. Writes replace files atomically, so readers never see a
+// partial value.
+type File[V any] struct {
+	dir string
+}
+
+// NewFile returns a File store in dir, creating the directory if needed.
+func NewFile[V any](dir string) (*File[V], error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &File[V]{dir: dir}, nil
+}
+
+// Get returns the value stored under key, if any.
+func (s *File[V]) Get(ctx context.Context, key string) (optional.Optional[V], error) {
+	if err := ctx.Err(); err != nil {
+		return optional.Empty[V](), err
+	}
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return optional.Empty[V](), nil
+	}
+	if err != nil {
+		return optional.Empty[V](), err
+	}
+	var v V
+	if err := json.Unmarshal(data, &v); err != nil {
+		return optional.Empty[V](), err
+	}
+	return optional.OfNilable(v), nil
+}
+
+// Put stores value under key.
+func (s *File[V]) Put(ctx context.Context, key string, value V) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// Delete removes key.
+func (s *File[V]) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *File[V]) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+".json")
+}
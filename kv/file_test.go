@@ -0,0 +1,30 @@
+package kv
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	store, err := NewFile[int](filepath.Join(t.TempDir(), "store"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	testKV(t, store)
+}
+
+func TestFileCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFile[int](dir)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bad.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(context.Background(), "bad"); err == nil {
+		t.Errorf("Expected a decoding error, but got nil")
+	}
+}
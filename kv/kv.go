@@ -0,0 +1,68 @@
+// Package kv defines a key-value store contract whose lookups return
+// Optionals, so storage backends share one way of saying that a key does
+// not exist, together with in-memory and file-backed implementations.
+package kv
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hermann-craft/optional"
+)
+
+// KV is a key-value store. Get returns an empty Optional for a missing key,
+// or for a nil pointer, map, slice or interface value; errors are reserved
+// for failures of the store itself. Deleting a missing key is not an error.
+type KV[K comparable, V any] interface {
+	Get(ctx context.Context, key K) (optional.Optional[V], error)
+	Put(ctx context.Context, key K, value V) error
+	Delete(ctx context.Context, key K) error
+}
+
+// Memory is a KV held in memory, for tests and single-process caches. It is
+// safe for concurrent use.
+type Memory[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// NewMemory returns an empty Memory store.
+func NewMemory[K comparable, V any]() *Memory[K, V] {
+	return &Memory[K, V]{m: map[K]V{}}
+}
+
+// Get returns the value stored under key, if any.
+func (s *Memory[K, V]) Get(ctx context.Context, key K) (optional.Optional[V], error) {
+	if err := ctx.Err(); err != nil {
+		return optional.Empty[V](), err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[key]
+	if !ok {
+		return optional.Empty[V](), nil
+	}
+	return optional.OfNilable(v), nil
+}
+
+// Put stores value under key.
+func (s *Memory[K, V]) Put(ctx context.Context, key K, value V) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[key] = value
+	return nil
+}
+
+// Delete removes key.
+func (s *Memory[K, V]) Delete(ctx context.Context, key K) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, key)
+	return nil
+}
@@ -0,0 +1,53 @@
+package kv
+
+import (
+	"context"
+	"testing"
+)
+
+// testKV runs the contract every KV implementation must satisfy.
+func testKV(t *testing.T, store KV[string, int]) {
+	ctx := context.Background()
+	if got, err := store.Get(ctx, "missing"); err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional for a missing key, but got %v, %v", got, err)
+	}
+	if err := store.Put(ctx, "a/b", 1); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if err := store.Put(ctx, "a/b", 2); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if got, err := store.Get(ctx, "a/b"); err != nil || got.OrZero() != 2 {
+		t.Errorf("Expected Optional[2], but got %v, %v", got, err)
+	}
+	if err := store.Delete(ctx, "a/b"); err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+	if err := store.Delete(ctx, "a/b"); err != nil {
+		t.Errorf("Expected no error deleting a missing key, but got %v", err)
+	}
+	if got, err := store.Get(ctx, "a/b"); err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional after Delete, but got %v, %v", got, err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := store.Get(cancelled, "a/b"); err == nil {
+		t.Errorf("Expected an error for a cancelled context, but got nil")
+	}
+}
+
+func TestMemory(t *testing.T) {
+	testKV(t, NewMemory[string, int]())
+}
+
+func TestMemoryNil(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory[string, *int]()
+	if err := store.Put(ctx, "p", nil); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if got, err := store.Get(ctx, "p"); err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional for a nil value, but got %v, %v", got, err)
+	}
+}
@@ -0,0 +1,63 @@
+package optional
+
+// Lens focuses on an optional part A of a value S, letting it be read and
+// updated immutably. Lenses compose with Compose to reach deeply nested
+// fields without hand-written copy-and-set code at each level:
+//
+//	address := optional.LensFor(
+//		func(u User) optional.Optional[Address] { return u.Address },
+//		func(u User, a Address) User { u.Address = optional.Of(a); return u },
+//	)
+//	city := optional.LensFor(
+//		func(a Address) optional.Optional[string] { return a.City },
+//		func(a Address, c string) Address { a.City = optional.Of(c); return a },
+//	)
+//	user = optional.Compose(address, city).Set(user, "Berlin")
+type Lens[S, A any] struct {
+	get func(S) Optional[A]
+	set func(S, A) S
+}
+
+// LensFor returns a Lens reading the part with get and replacing it with
+// set, which must return an updated copy of its argument.
+func LensFor[S, A any](get func(S) Optional[A], set func(S, A) S) Lens[S, A] {
+	return Lens[S, A]{get: get, set: set}
+}
+
+// Get returns the part of s the Lens focuses on.
+func (l Lens[S, A]) Get(s S) Optional[A] {
+	return l.get(s)
+}
+
+// Set returns a copy of s with the part replaced by a.
+func (l Lens[S, A]) Set(s S, a A) S {
+	return l.set(s, a)
+}
+
+// Modify returns a copy of s with fn applied to the part, or s unchanged if
+// the part is empty.
+func (l Lens[S, A]) Modify(s S, fn func(A) A) S {
+	a := l.get(s)
+	if a.IsEmpty() {
+		return s
+	}
+	return l.set(s, fn(a.Get()))
+}
+
+// Compose returns a Lens focusing on the part B of the part A of S. Setting
+// through it when the intermediate part is empty starts from the zero value
+// of A.
+func Compose[S, A, B any](outer Lens[S, A], inner Lens[A, B]) Lens[S, B] {
+	return Lens[S, B]{
+		get: func(s S) Optional[B] {
+			return FlatMap(outer.get(s), inner.get)
+		},
+		set: func(s S, b B) S {
+			var a A
+			if o := outer.get(s); o.IsPresent() {
+				a = o.Get()
+			}
+			return outer.set(s, inner.set(a, b))
+		},
+	}
+}
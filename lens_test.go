@@ -0,0 +1,59 @@
+package optional
+
+import "testing"
+
+type lensAddress struct {
+	City Optional[string]
+	Zip  Optional[string]
+}
+
+type lensUser struct {
+	Name    string
+	Address Optional[lensAddress]
+}
+
+var (
+	addressLens = LensFor(
+		func(u lensUser) Optional[lensAddress] { return u.Address },
+		func(u lensUser, a lensAddress) lensUser { u.Address = Of(a); return u },
+	)
+	cityLens = LensFor(
+		func(a lensAddress) Optional[string] { return a.City },
+		func(a lensAddress, c string) lensAddress { a.City = Of(c); return a },
+	)
+	userCity = Compose(addressLens, cityLens)
+)
+
+func TestLens(t *testing.T) {
+	u := lensUser{Name: "Alice", Address: Of(lensAddress{City: Of("Berlin"), Zip: Of("10115")})}
+	if got := userCity.Get(u); got.OrElse("") != "Berlin" {
+		t.Errorf("Expected Optional[Berlin], but got %v", got)
+	}
+
+	moved := userCity.Set(u, "Hamburg")
+	if userCity.Get(moved).OrElse("") != "Hamburg" || moved.Address.Get().Zip.OrElse("") != "10115" {
+		t.Errorf("Expected Hamburg 10115, but got %v", moved.Address)
+	}
+	if userCity.Get(u).OrElse("") != "Berlin" {
+		t.Errorf("Expected the original to be unchanged, but got %v", u.Address)
+	}
+
+	upper := userCity.Modify(u, func(c string) string { return c + "!" })
+	if userCity.Get(upper).OrElse("") != "Berlin!" {
+		t.Errorf("Expected Berlin!, but got %v", userCity.Get(upper))
+	}
+}
+
+func TestLensEmpty(t *testing.T) {
+	u := lensUser{Name: "Bob"}
+	if got := userCity.Get(u); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	if got := userCity.Modify(u, func(c string) string { return c + "!" }); got.Address.IsPresent() {
+		t.Errorf("Expected Modify to leave an empty part alone, but got %v", got.Address)
+	}
+	got := userCity.Set(u, "Paris")
+	if userCity.Get(got).OrElse("") != "Paris" || got.Address.Get().Zip.IsPresent() {
+		t.Errorf("Expected a new address in Paris, but got %v", got.Address)
+	}
+}
@@ -0,0 +1,84 @@
+package optional
+
+// Lift turns fn into a function on Optionals that applies fn to a present
+// value, like Map.
+func Lift[T, U any](fn func(T) U) func(Optional[T]) Optional[U] {
+	return func(opt Optional[T]) Optional[U] {
+		return Map(opt, fn)
+	}
+}
+
+// LiftErr is like Lift for functions that may fail. The lifted function
+// returns an empty Optional and fn's error when fn fails, and an empty
+// Optional without error when its argument is empty.
+func LiftErr[T, U any](fn func(T) (U, error)) func(Optional[T]) (Optional[U], error) {
+	return func(opt Optional[T]) (Optional[U], error) {
+		if opt.IsEmpty() {
+			return Empty[U](), nil
+		}
+		u, err := fn(opt.Get())
+		if err != nil {
+			return Empty[U](), err
+		}
+		return ofResult(u), nil
+	}
+}
+
+// Apply applies the function held by optFn to the value held by opt. The
+// result is empty unless both are present.
+func Apply[T, U any](optFn Optional[func(T) U], opt Optional[T]) Optional[U] {
+	if optFn.IsEmpty() {
+		return Empty[U]()
+	}
+	return Map(opt, optFn.Get())
+}
+
+// Map2 applies fn to the values of a and b if both are present.
+func Map2[A, B, C any](a Optional[A], b Optional[B], fn func(A, B) C) Optional[C] {
+	if a.IsEmpty() || b.IsEmpty() {
+		return Empty[C]()
+	}
+	return ofResult(fn(a.Get(), b.Get()))
+}
+
+// Map3 applies fn to the values of a, b and c if all are present.
+func Map3[A, B, C, D any](a Optional[A], b Optional[B], c Optional[C], fn func(A, B, C) D) Optional[D] {
+	if a.IsEmpty() || b.IsEmpty() || c.IsEmpty() {
+		return Empty[D]()
+	}
+	return ofResult(fn(a.Get(), b.Get(), c.Get()))
+}
+
+// Bind2 is like Map2 for a function returning an Optional, whose result is
+// returned directly, as with FlatMap.
+func Bind2[A, B, C any](a Optional[A], b Optional[B], fn func(A, B) Optional[C]) Optional[C] {
+	if a.IsEmpty() || b.IsEmpty() {
+		return Empty[C]()
+	}
+	return fn(a.Get(), b.Get())
+}
+
+// Bind3 is like Map3 for a function returning an Optional, whose result is
+// returned directly, as with FlatMap.
+func Bind3[A, B, C, D any](a Optional[A], b Optional[B], c Optional[C], fn func(A, B, C) Optional[D]) Optional[D] {
+	if a.IsEmpty() || b.IsEmpty() || c.IsEmpty() {
+		return Empty[D]()
+	}
+	return fn(a.Get(), b.Get(), c.Get())
+}
+
+// Pipe applies fns in order to the value of opt if present, like
+// Optional.Normalize.
+func Pipe[T any](opt Optional[T], fns ...func(T) T) Optional[T] {
+	return opt.Normalize(fns...)
+}
+
+// Pipe2 applies f and then g to the value of opt if present.
+func Pipe2[A, B, C any](opt Optional[A], f func(A) B, g func(B) C) Optional[C] {
+	return Map(opt, func(a A) C { return g(f(a)) })
+}
+
+// Pipe3 applies f, g and then h to the value of opt if present.
+func Pipe3[A, B, C, D any](opt Optional[A], f func(A) B, g func(B) C, h func(C) D) Optional[D] {
+	return Map(opt, func(a A) D { return h(g(f(a))) })
+}
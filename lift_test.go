@@ -0,0 +1,105 @@
+package optional
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestLift(t *testing.T) {
+	length := Lift(func(s string) int { return len(s) })
+	if got := length(Of("hello")); got.OrElse(0) != 5 {
+		t.Errorf("Expected Optional[5], but got %v", got)
+	}
+	if got := length(Empty[string]()); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestLiftErr(t *testing.T) {
+	atoi := LiftErr(strconv.Atoi)
+	if got, err := atoi(Of("42")); err != nil || got.OrElse(0) != 42 {
+		t.Errorf("Expected Optional[42], but got %v (%v)", got, err)
+	}
+	if got, err := atoi(Of("x")); err == nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional and an error, but got %v (%v)", got, err)
+	}
+	if got, err := atoi(Empty[string]()); err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional and no error, but got %v (%v)", got, err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	double := Of(func(n int) int { return n * 2 })
+	if got := Apply(double, Of(21)); got.OrElse(0) != 42 {
+		t.Errorf("Expected Optional[42], but got %v", got)
+	}
+	if got := Apply(double, Empty[int]()); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	if got := Apply(Empty[func(int) int](), Of(21)); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestMapN(t *testing.T) {
+	join := func(a string, b int) string { return a + strconv.Itoa(b) }
+	if got := Map2(Of("a"), Of(1), join); got.OrElse("") != "a1" {
+		t.Errorf("Expected Optional[a1], but got %v", got)
+	}
+	if got := Map2(Of("a"), Empty[int](), join); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	sum := func(a, b, c int) int { return a + b + c }
+	if got := Map3(Of(1), Of(2), Of(3), sum); got.OrElse(0) != 6 {
+		t.Errorf("Expected Optional[6], but got %v", got)
+	}
+	if got := Map3(Empty[int](), Of(2), Of(3), sum); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestBindN(t *testing.T) {
+	div := func(a, b int) Optional[int] {
+		if b == 0 {
+			return Empty[int]()
+		}
+		return Of(a / b)
+	}
+	if got := Bind2(Of(6), Of(3), div); got.OrElse(0) != 2 {
+		t.Errorf("Expected Optional[2], but got %v", got)
+	}
+	if got := Bind2(Of(6), Of(0), div); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	if got := Bind2(Empty[int](), Of(3), div); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	calls := 0
+	div3 := func(a, b, c int) Optional[int] {
+		calls++
+		return Bind2(Of(a), Of(b*c), div)
+	}
+	if got := Bind3(Of(12), Of(2), Of(3), div3); got.OrElse(0) != 2 {
+		t.Errorf("Expected Optional[2], but got %v", got)
+	}
+	if got := Bind3(Of(12), Empty[int](), Of(3), div3); got.IsPresent() || calls != 1 {
+		t.Errorf("Expected an empty Optional without calling fn, but got %v after %d calls", got, calls)
+	}
+}
+
+func TestPipe(t *testing.T) {
+	inc := func(n int) int { return n + 1 }
+	if got := Pipe(Of(1), inc, inc); got.OrElse(0) != 3 {
+		t.Errorf("Expected Optional[3], but got %v", got)
+	}
+	if got := Pipe2(Of(41), inc, strconv.Itoa); got.OrElse("") != "42" {
+		t.Errorf("Expected Optional[42], but got %v", got)
+	}
+	length := func(s string) int { return len(s) }
+	if got := Pipe3(Of(99), inc, strconv.Itoa, length); got.OrElse(0) != 3 {
+		t.Errorf("Expected Optional[3], but got %v", got)
+	}
+	if got := Pipe3(Empty[int](), inc, strconv.Itoa, length); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
@@ -0,0 +1,31 @@
+package optional
+
+import "iter"
+
+// CompactMap returns the present values of m keyed like in m, dropping the
+// keys whose Optional is empty, for post-processing aggregation results
+// keyed by ID with possibly missing values.
+func CompactMap[K comparable, V any](m map[K]Optional[V]) map[K]V {
+	compact := make(map[K]V, len(m))
+	for k, v := range PresentEntries(m) {
+		compact[k] = v
+	}
+	return compact
+}
+
+// PresentEntries returns an iterator over the keys of m whose Optional is
+// present, together with their values, in the unspecified order of map
+// iteration:
+//
+//	for id, total := range optional.PresentEntries(totals) {
+//		report(id, total)
+//	}
+func PresentEntries[K comparable, V any](m map[K]Optional[V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, o := range m {
+			if o.IsPresent() && !yield(k, *o.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package optional
+
+import "testing"
+
+func TestCompactMap(t *testing.T) {
+	m := map[string]Optional[int]{"a": Of(1), "b": Empty[int](), "c": Of(0)}
+	got := CompactMap(m)
+	if len(got) != 2 || got["a"] != 1 || got["c"] != 0 {
+		t.Errorf("Expected map[a:1 c:0], but got %v", got)
+	}
+	if _, ok := got["b"]; ok {
+		t.Errorf("Expected the empty entry to be dropped, but it was kept")
+	}
+	if got := CompactMap[string, int](nil); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty non-nil map, but got %v", got)
+	}
+}
+
+func TestPresentEntries(t *testing.T) {
+	m := map[int]Optional[string]{1: Of("a"), 2: Empty[string](), 3: Of("c")}
+	seen := map[int]string{}
+	for k, v := range PresentEntries(m) {
+		seen[k] = v
+	}
+	if len(seen) != 2 || seen[1] != "a" || seen[3] != "c" {
+		t.Errorf("Expected map[1:a 3:c], but got %v", seen)
+	}
+	count := 0
+	for range PresentEntries(m) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("Expected iteration to stop after break, but got %d entries", count)
+	}
+}
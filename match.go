@@ -0,0 +1,40 @@
+package optional
+
+// Case is a guarded branch of MatchWhen.
+type Case[T, R any] struct {
+	pred    func(T) bool
+	handler func(T) R
+}
+
+// When returns a Case that applies handler to values matching pred.
+func When[T, R any](pred func(T) bool, handler func(T) R) Case[T, R] {
+	return Case[T, R]{pred: pred, handler: handler}
+}
+
+// Otherwise returns a Case that applies handler to any value, for use as the
+// last case of MatchWhen.
+func Otherwise[T, R any](handler func(T) R) Case[T, R] {
+	return Case[T, R]{handler: handler}
+}
+
+// MatchWhen returns the result of the handler of the first case matching the
+// value of o, or of onEmpty if o is empty. It returns the zero value of R if
+// no case matches.
+//
+//	label := optional.MatchWhen(age,
+//		func() string { return "unknown" },
+//		optional.When(func(n int) bool { return n < 18 }, func(int) string { return "minor" }),
+//		optional.Otherwise(func(int) string { return "adult" }),
+//	)
+func MatchWhen[T, R any](o Optional[T], onEmpty func() R, cases ...Case[T, R]) R {
+	if o.value == nil {
+		return onEmpty()
+	}
+	for _, c := range cases {
+		if c.pred == nil || c.pred(*o.value) {
+			return c.handler(*o.value)
+		}
+	}
+	var zero R
+	return zero
+}
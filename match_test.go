@@ -0,0 +1,37 @@
+package optional
+
+import "testing"
+
+func TestMatchWhen(t *testing.T) {
+	label := func(o Optional[int]) string {
+		return MatchWhen(o,
+			func() string { return "unknown" },
+			When(func(n int) bool { return n < 0 }, func(int) string { return "invalid" }),
+			When(func(n int) bool { return n < 18 }, func(int) string { return "minor" }),
+			Otherwise(func(int) string { return "adult" }),
+		)
+	}
+	tests := []struct {
+		in   Optional[int]
+		want string
+	}{
+		{Empty[int](), "unknown"},
+		{Of(-1), "invalid"},
+		{Of(10), "minor"},
+		{Of(30), "adult"},
+	}
+	for _, tt := range tests {
+		if got := label(tt.in); got != tt.want {
+			t.Errorf("Expected %q for %v, but got %q", tt.want, tt.in, got)
+		}
+	}
+}
+
+func TestMatchWhenNoMatch(t *testing.T) {
+	got := MatchWhen(Of(5), func() int { return -1 },
+		When(func(n int) bool { return n > 10 }, func(n int) int { return n }),
+	)
+	if got != 0 {
+		t.Errorf("Expected the zero value, but got %d", got)
+	}
+}
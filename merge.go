@@ -0,0 +1,90 @@
+package optional
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// Merge returns a copy of base in which every Optional field present in
+// overlay replaces the corresponding field of base. Nested structs, pointers
+// to structs and Optionals holding structs are merged recursively; other
+// fields keep the value they have in base. Neither argument is modified.
+//
+// Merge makes layered configuration straightforward:
+//
+//	config := Merge(Merge(Merge(defaults, file), env), flags)
+func Merge[T any](base, overlay T) T {
+	result := base
+	merge(reflect.ValueOf(&result).Elem(), reflect.ValueOf(overlay))
+	return result
+}
+
+func merge(dst, overlay reflect.Value) {
+	if dst.Kind() != reflect.Struct {
+		return
+	}
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		df, of := dst.Field(i), overlay.Field(i)
+		switch {
+		case isOptionalType(df.Type()):
+			ov, present := optionalValue(of)
+			if !present {
+				continue
+			}
+			if bv, ok := optionalValue(df); ok && ov.Kind() == reflect.Struct {
+				merge(bv, ov)
+				setOptional(df, bv)
+				continue
+			}
+			df.Set(of)
+		case df.Kind() == reflect.Struct:
+			merge(df, of)
+		case df.Kind() == reflect.Pointer && df.Type().Elem().Kind() == reflect.Struct:
+			if of.IsNil() {
+				continue
+			}
+			if df.IsNil() {
+				df.Set(of)
+				continue
+			}
+			p := reflect.New(df.Type().Elem())
+			p.Elem().Set(df.Elem())
+			merge(p.Elem(), of.Elem())
+			df.Set(p)
+		}
+	}
+}
+
+// MergeWith combines the values of a and b with combine if both are present.
+// Otherwise it returns whichever is present, so an empty Optional acts as
+// the identity.
+func MergeWith[T any](a, b Optional[T], combine func(T, T) T) Optional[T] {
+	switch {
+	case a.IsEmpty():
+		return b
+	case b.IsEmpty():
+		return a
+	}
+	return Of(combine(a.Get(), b.Get()))
+}
+
+// MergeSlices merges two optional slices by concatenating them.
+func MergeSlices[S ~[]E, E any](a, b Optional[S]) Optional[S] {
+	return MergeWith(a, b, func(x, y S) S { return slices.Concat(x, y) })
+}
+
+// MergeMaps merges two optional maps into a new map holding the entries of
+// both, with those of b taking precedence.
+func MergeMaps[M ~map[K]V, K comparable, V any](a, b Optional[M]) Optional[M] {
+	return MergeWith(a, b, func(x, y M) M {
+		m := make(M, len(x)+len(y))
+		maps.Copy(m, x)
+		maps.Copy(m, y)
+		return m
+	})
+}
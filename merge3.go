@@ -0,0 +1,74 @@
+package optional
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Conflict describes a field changed differently by both sides of a
+// three-way merge.
+type Conflict struct {
+	Field  string // dotted path of the field
+	Mine   any
+	Theirs any
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s: %v != %v", c.Field, c.Mine, c.Theirs)
+}
+
+// Merge3 merges two concurrent revisions, mine and theirs, of the common
+// ancestor base. A field changed on one side only takes that side's value;
+// a field changed on both sides to different values is a conflict, for which
+// the result keeps mine. Optional and Nullable fields, and structs without
+// exported fields such as time.Time, are compared as a whole; other nested
+// structs are merged field by field.
+//
+// Merge3 suits optimistic-concurrency updates: when a save fails because the
+// stored entity moved on, the client's edits can be rebased onto it and only
+// genuine conflicts reported.
+func Merge3[P any](base, mine, theirs P) (P, []Conflict) {
+	result := mine
+	var conflicts []Conflict
+	merge3(reflect.ValueOf(&result).Elem(), reflect.ValueOf(base), reflect.ValueOf(mine), reflect.ValueOf(theirs), "", &conflicts)
+	return result, conflicts
+}
+
+func merge3(dst, base, mine, theirs reflect.Value, path string, conflicts *[]Conflict) {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := path + f.Name
+		b, m, th := base.Field(i), mine.Field(i), theirs.Field(i)
+		if f.Type.Kind() == reflect.Struct && hasExportedFields(f.Type) && !isOptionalType(f.Type) && !isNullableType(f.Type) {
+			merge3(dst.Field(i), b, m, th, name+".", conflicts)
+			continue
+		}
+		switch {
+		case sameField(b, m):
+			dst.Field(i).Set(th)
+		case sameField(b, th), sameField(m, th):
+		default:
+			*conflicts = append(*conflicts, Conflict{Field: name, Mine: m.Interface(), Theirs: th.Interface()})
+		}
+	}
+}
+
+// sameField reports whether the fields a and b hold equal values. Optionals
+// and Nullables are compared by content, ignoring how they were created.
+func sameField(a, b reflect.Value) bool {
+	switch {
+	case isNullableType(a.Type()):
+		as, av, ap := a.Interface().(reflectedNullable).nullableValue()
+		bs, bv, bp := b.Interface().(reflectedNullable).nullableValue()
+		return as == bs && ap == bp && (!ap || reflect.DeepEqual(av.Interface(), bv.Interface()))
+	case isOptionalType(a.Type()):
+		av, ap := optionalValue(a)
+		bv, bp := optionalValue(b)
+		return ap == bp && (!ap || reflect.DeepEqual(av.Interface(), bv.Interface()))
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
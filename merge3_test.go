@@ -0,0 +1,93 @@
+package optional
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type merge3Address struct {
+	City Optional[string]
+	Zip  Optional[string]
+}
+
+type merge3Patch struct {
+	Name    Optional[string]
+	Email   Optional[string]
+	Phone   Nullable[string]
+	Version int
+	Address merge3Address
+}
+
+func TestMerge3(t *testing.T) {
+	base := merge3Patch{
+		Name:    Of("Alice"),
+		Email:   Of("alice@example.com"),
+		Phone:   NullableOf("555"),
+		Version: 1,
+		Address: merge3Address{City: Of("Berlin")},
+	}
+	mine := base
+	mine.Name = Of("Alicia")
+	mine.Phone = Null[string]()
+	mine.Address.Zip = Of("10115")
+	theirs := base
+	theirs.Email = Empty[string]()
+	theirs.Version = 2
+	theirs.Address.City = Of("Hamburg")
+
+	merged, conflicts := Merge3(base, mine, theirs)
+	if len(conflicts) != 0 {
+		t.Errorf("Expected no conflicts, but got %v", conflicts)
+	}
+	if merged.Name.OrElse("") != "Alicia" || merged.Email.IsPresent() || !merged.Phone.IsNull() || merged.Version != 2 {
+		t.Errorf("Expected both sides' changes, but got %+v", merged)
+	}
+	if merged.Address.City.OrElse("") != "Hamburg" || merged.Address.Zip.OrElse("") != "10115" {
+		t.Errorf("Expected Hamburg 10115, but got %v %v", merged.Address.City, merged.Address.Zip)
+	}
+}
+
+func TestMerge3Conflicts(t *testing.T) {
+	base := merge3Patch{Name: Of("Alice"), Address: merge3Address{City: Of("Berlin")}}
+	mine, theirs := base, base
+	mine.Name, theirs.Name = Of("Alicia"), Of("Ali")
+	mine.Address.City, theirs.Address.City = Of("Hamburg"), Of("Hamburg")
+	mine.Address.Zip, theirs.Address.Zip = Of("10115"), Empty[string]()
+	mine.Email, theirs.Email = Empty[string](), Empty[string]()
+
+	merged, conflicts := Merge3(base, mine, theirs)
+	want := []Conflict{
+		{Field: "Name", Mine: Of("Alicia"), Theirs: Of("Ali")},
+	}
+	if len(conflicts) != 1 || conflicts[0].Field != "Name" || !reflect.DeepEqual(conflicts[0].Mine, want[0].Mine) {
+		t.Errorf("Expected %v, but got %v", want, conflicts)
+	}
+	if merged.Name.OrElse("") != "Alicia" {
+		t.Errorf("Expected conflicting fields to keep mine, but got %v", merged.Name)
+	}
+	if merged.Address.Zip.OrElse("") != "10115" {
+		t.Errorf("Expected 10115, but got %v", merged.Address.Zip)
+	}
+}
+
+func TestMerge3OpaqueStruct(t *testing.T) {
+	type event struct {
+		At time.Time
+	}
+	base := event{At: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	mine, theirs := base, base
+	theirs.At = base.At.Add(time.Hour)
+	if merged, conflicts := Merge3(base, mine, theirs); len(conflicts) != 0 || !merged.At.Equal(theirs.At) {
+		t.Errorf("Expected %v without conflicts, but got %v %v", theirs.At, merged.At, conflicts)
+	}
+
+	mine.At = base.At.Add(2 * time.Hour)
+	merged, conflicts := Merge3(base, mine, theirs)
+	if len(conflicts) != 1 || conflicts[0].Field != "At" {
+		t.Errorf("Expected a conflict on At, but got %v", conflicts)
+	}
+	if !merged.At.Equal(mine.At) {
+		t.Errorf("Expected conflicting fields to keep mine, but got %v", merged.At)
+	}
+}
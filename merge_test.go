@@ -0,0 +1,100 @@
+package optional
+
+import "testing"
+
+type mergeTLS struct {
+	Enabled Optional[bool]
+	Cert    Optional[string]
+}
+
+type mergeConfig struct {
+	Host    Optional[string]
+	Port    Optional[int]
+	Name    string
+	TLS     mergeTLS
+	Auth    Optional[mergeTLS]
+	Backend *mergeTLS
+}
+
+func TestMerge(t *testing.T) {
+	base := mergeConfig{
+		Host:    Of("localhost"),
+		Port:    Of(80),
+		Name:    "base",
+		TLS:     mergeTLS{Enabled: Of(false), Cert: Of("base.pem")},
+		Auth:    Of(mergeTLS{Enabled: Of(true), Cert: Of("auth.pem")}),
+		Backend: &mergeTLS{Cert: Of("backend.pem")},
+	}
+	overlay := mergeConfig{
+		Port:    Of(8080),
+		Name:    "overlay",
+		TLS:     mergeTLS{Enabled: Of(true)},
+		Auth:    Of(mergeTLS{Cert: Of("override.pem")}),
+		Backend: &mergeTLS{Enabled: Of(true)},
+	}
+
+	merged := Merge(base, overlay)
+	if merged.Host.OrElse("") != "localhost" || merged.Port.OrElse(0) != 8080 {
+		t.Errorf("Expected localhost:8080, but got %v:%v", merged.Host, merged.Port)
+	}
+	if merged.Name != "base" {
+		t.Errorf("Expected plain field to keep base value, but got %q", merged.Name)
+	}
+	if !merged.TLS.Enabled.OrElse(false) || merged.TLS.Cert.OrElse("") != "base.pem" {
+		t.Errorf("Expected nested struct to be merged, but got %+v", merged.TLS)
+	}
+	auth := merged.Auth.Get()
+	if !auth.Enabled.OrElse(false) || auth.Cert.OrElse("") != "override.pem" {
+		t.Errorf("Expected Optional struct to be merged, but got %+v", auth)
+	}
+	if !merged.Backend.Enabled.OrElse(false) || merged.Backend.Cert.OrElse("") != "backend.pem" {
+		t.Errorf("Expected pointer struct to be merged, but got %+v", merged.Backend)
+	}
+	if base.Port.Get() != 80 || base.Backend.Enabled.IsPresent() || base.Auth.Get().Cert.Get() != "auth.pem" {
+		t.Errorf("Expected base to be left unmodified, but got %+v", base)
+	}
+}
+
+func TestMergeEmptyOverlay(t *testing.T) {
+	base := mergeConfig{Host: Of("localhost")}
+	merged := Merge(base, mergeConfig{})
+	if merged.Host.OrElse("") != "localhost" || merged.Port.IsPresent() {
+		t.Errorf("Expected empty overlay to keep base, but got %+v", merged)
+	}
+}
+
+func TestMergeWith(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+	if got := MergeWith(Of(1), Of(2), add); got.OrElse(0) != 3 {
+		t.Errorf("Expected Optional[3], but got %v", got)
+	}
+	if got := MergeWith(Empty[int](), Of(2), add); got.OrElse(0) != 2 {
+		t.Errorf("Expected Optional[2], but got %v", got)
+	}
+	if got := MergeWith(Of(1), Empty[int](), add); got.OrElse(0) != 1 {
+		t.Errorf("Expected Optional[1], but got %v", got)
+	}
+	if got := MergeWith(Empty[int](), Empty[int](), add); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestMergeCollections(t *testing.T) {
+	a := []string{"a"}
+	got := MergeSlices(Of(a[:1:1]), Of([]string{"b", "c"})).Get()
+	if len(got) != 3 || got[0] != "a" || got[2] != "c" || a[0] != "a" {
+		t.Errorf("Expected [a b c], but got %v", got)
+	}
+
+	x := map[string]int{"a": 1, "b": 2}
+	m := MergeMaps(Of(x), Of(map[string]int{"b": 3, "c": 4})).Get()
+	if len(m) != 3 || m["a"] != 1 || m["b"] != 3 || m["c"] != 4 {
+		t.Errorf("Expected map[a:1 b:3 c:4], but got %v", m)
+	}
+	if x["b"] != 2 || len(x) != 2 {
+		t.Errorf("Expected the input map to be unchanged, but got %v", x)
+	}
+	if got := MergeMaps(Of[map[string]int](nil), Of(x)).Get(); len(got) != 2 {
+		t.Errorf("Expected the present map, but got %v", got)
+	}
+}
@@ -0,0 +1,34 @@
+// Package moopt converts between Optional and the Option type of
+// github.com/samber/mo, for codebases migrating from one to the other.
+//
+// The package relies only on the Get method and the TupleToOption
+// constructor of mo, so it does not import the library itself:
+//
+//	id := moopt.FromMo[int](user.ID)
+//	user.ID = moopt.ToMo(id, mo.TupleToOption[int])
+package moopt
+
+import "github.com/hermann-craft/optional"
+
+// Option is the method set of mo.Option[T] the package uses.
+type Option[T any] interface {
+	Get() (T, bool)
+}
+
+// FromMo converts a mo.Option into an Optional.
+func FromMo[T any, O Option[T]](o O) optional.Optional[T] {
+	if v, ok := o.Get(); ok {
+		return optional.Of(v)
+	}
+	return optional.Empty[T]()
+}
+
+// ToMo converts an Optional into a mo.Option using the TupleToOption
+// constructor of mo.
+func ToMo[O, T any](o optional.Optional[T], fromTuple func(T, bool) O) O {
+	if o.IsEmpty() {
+		var zero T
+		return fromTuple(zero, false)
+	}
+	return fromTuple(o.Get(), true)
+}
@@ -0,0 +1,41 @@
+package moopt
+
+import (
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+// option mirrors mo.Option.
+type option[T any] struct {
+	isPresent bool
+	value     T
+}
+
+func (o option[T]) Get() (T, bool) { return o.value, o.isPresent }
+
+// tupleToOption mirrors mo.TupleToOption.
+func tupleToOption[T any](value T, ok bool) option[T] {
+	if !ok {
+		return option[T]{}
+	}
+	return option[T]{isPresent: true, value: value}
+}
+
+func TestFromMo(t *testing.T) {
+	if got := FromMo[int](option[int]{isPresent: true, value: 42}); got.OrElse(0) != 42 {
+		t.Errorf("Expected Optional[42], but got %v", got)
+	}
+	if got := FromMo[int](option[int]{}); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestToMo(t *testing.T) {
+	if got := ToMo(optional.Of("a"), tupleToOption[string]); !got.isPresent || got.value != "a" {
+		t.Errorf("Expected Some(a), but got %v", got)
+	}
+	if got := ToMo(optional.Empty[string](), tupleToOption[string]); got.isPresent {
+		t.Errorf("Expected None, but got %v", got)
+	}
+}
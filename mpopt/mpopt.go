@@ -0,0 +1,42 @@
+// Package mpopt converts between Optional and the generated named types of
+// github.com/markphelps/optional, such as optional.String and
+// optional.Int64, so code using that library can migrate incrementally.
+//
+// The package relies only on the method set the generated types share, so
+// it does not import the library itself:
+//
+//	name := mpopt.From[string](user.Name)
+//	user.Name = mpopt.To(name, mp.NewString)
+package mpopt
+
+import "github.com/hermann-craft/optional"
+
+// Value is the method set shared by the markphelps/optional types holding a
+// T.
+type Value[T any] interface {
+	Get() (T, error)
+	Present() bool
+}
+
+// From converts a markphelps/optional value holding a T into an Optional.
+func From[T any, O Value[T]](o O) optional.Optional[T] {
+	if !o.Present() {
+		return optional.Empty[T]()
+	}
+	v, err := o.Get()
+	if err != nil {
+		return optional.Empty[T]()
+	}
+	return optional.Of(v)
+}
+
+// To converts an Optional into a markphelps/optional value using the
+// library's constructor for it, such as optional.NewString. An empty
+// Optional yields the zero value of O, which the library treats as empty.
+func To[O, T any](o optional.Optional[T], newFn func(T) O) O {
+	if o.IsEmpty() {
+		var zero O
+		return zero
+	}
+	return newFn(o.Get())
+}
@@ -0,0 +1,47 @@
+package mpopt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+// mpString mirrors the optional.String type generated by
+// markphelps/optional.
+type mpString struct {
+	value *string
+}
+
+func newMPString(v string) mpString {
+	return mpString{value: &v}
+}
+
+func (s mpString) Get() (string, error) {
+	if !s.Present() {
+		return "", errors.New("value not present")
+	}
+	return *s.value, nil
+}
+
+func (s mpString) Present() bool {
+	return s.value != nil
+}
+
+func TestFrom(t *testing.T) {
+	if got := From[string](newMPString("a")); got.OrElse("") != "a" {
+		t.Errorf("Expected Optional[a], but got %v", got)
+	}
+	if got := From[string](mpString{}); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestTo(t *testing.T) {
+	if got := To(optional.Of("a"), newMPString); !got.Present() || *got.value != "a" {
+		t.Errorf("Expected a present value a, but got %v", got)
+	}
+	if got := To(optional.Empty[string](), newMPString); got.Present() {
+		t.Errorf("Expected an empty value, but got %v", got)
+	}
+}
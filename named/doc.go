@@ -0,0 +1,8 @@
+// Package named provides concrete optional types for encoders, ORMs and
+// serializers that cannot handle the generic optional.Optional.
+//
+// Every type converts to and from its generic counterpart with its Optional
+// method and the matching From function.
+package named
+
+//go:generate go run github.com/hermann-craft/optional/cmd/optionaltypes -package named -output named.go string int int32 int64 float32 float64 bool byte time.Time time.Duration
@@ -0,0 +1,1023 @@
+// Code generated by optionaltypes. DO NOT EDIT.
+
+package named
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+// OptionalString is a concrete form of optional.Optional[string].
+type OptionalString struct {
+	value   string
+	present bool
+}
+
+// OfString returns an OptionalString containing v.
+func OfString(v string) OptionalString {
+	return OptionalString{value: v, present: true}
+}
+
+// FromString converts a generic Optional into an OptionalString.
+func FromString(o optional.Optional[string]) OptionalString {
+	if o.IsEmpty() {
+		return OptionalString{}
+	}
+	return OfString(o.Get())
+}
+
+// Optional converts o into a generic Optional.
+func (o OptionalString) Optional() optional.Optional[string] {
+	if !o.present {
+		return optional.Empty[string]()
+	}
+	return optional.Of(o.value)
+}
+
+// IsPresent returns true if o contains a value.
+func (o OptionalString) IsPresent() bool {
+	return o.present
+}
+
+// IsEmpty returns true if o does not contain a value.
+func (o OptionalString) IsEmpty() bool {
+	return !o.present
+}
+
+// Get returns the value if present, otherwise it panics.
+func (o OptionalString) Get() string {
+	if !o.present {
+		panic("OptionalString.Get: no value present")
+	}
+	return o.value
+}
+
+// OrElse returns the value if present, otherwise returns other.
+func (o OptionalString) OrElse(other string) string {
+	if o.present {
+		return o.value
+	}
+	return other
+}
+
+// String returns a string representation of o.
+func (o OptionalString) String() string {
+	if o.present {
+		return fmt.Sprintf("Optional[%v]", o.value)
+	}
+	return "Optional.empty"
+}
+
+// MarshalJSON encodes the value, or null when o is empty.
+func (o OptionalString) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value into o, leaving it empty for null.
+func (o *OptionalString) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = OptionalString{}
+		return nil
+	}
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = OfString(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, leaving o empty for NULL.
+func (o *OptionalString) Scan(src any) error {
+	var n sql.Null[string]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = OptionalString{value: n.V, present: n.Valid}
+	return nil
+}
+
+// Value implements driver.Valuer, returning NULL when o is empty.
+func (o OptionalString) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// OptionalInt is a concrete form of optional.Optional[int].
+type OptionalInt struct {
+	value   int
+	present bool
+}
+
+// OfInt returns an OptionalInt containing v.
+func OfInt(v int) OptionalInt {
+	return OptionalInt{value: v, present: true}
+}
+
+// FromInt converts a generic Optional into an OptionalInt.
+func FromInt(o optional.Optional[int]) OptionalInt {
+	if o.IsEmpty() {
+		return OptionalInt{}
+	}
+	return OfInt(o.Get())
+}
+
+// Optional converts o into a generic Optional.
+func (o OptionalInt) Optional() optional.Optional[int] {
+	if !o.present {
+		return optional.Empty[int]()
+	}
+	return optional.Of(o.value)
+}
+
+// IsPresent returns true if o contains a value.
+func (o OptionalInt) IsPresent() bool {
+	return o.present
+}
+
+// IsEmpty returns true if o does not contain a value.
+func (o OptionalInt) IsEmpty() bool {
+	return !o.present
+}
+
+// Get returns the value if present, otherwise it panics.
+func (o OptionalInt) Get() int {
+	if !o.present {
+		panic("OptionalInt.Get: no value present")
+	}
+	return o.value
+}
+
+// OrElse returns the value if present, otherwise returns other.
+func (o OptionalInt) OrElse(other int) int {
+	if o.present {
+		return o.value
+	}
+	return other
+}
+
+// String returns a string representation of o.
+func (o OptionalInt) String() string {
+	if o.present {
+		return fmt.Sprintf("Optional[%v]", o.value)
+	}
+	return "Optional.empty"
+}
+
+// MarshalJSON encodes the value, or null when o is empty.
+func (o OptionalInt) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value into o, leaving it empty for null.
+func (o *OptionalInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = OptionalInt{}
+		return nil
+	}
+	var v int
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = OfInt(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, leaving o empty for NULL.
+func (o *OptionalInt) Scan(src any) error {
+	var n sql.Null[int]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = OptionalInt{value: n.V, present: n.Valid}
+	return nil
+}
+
+// Value implements driver.Valuer, returning NULL when o is empty.
+func (o OptionalInt) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// OptionalInt32 is a concrete form of optional.Optional[int32].
+type OptionalInt32 struct {
+	value   int32
+	present bool
+}
+
+// OfInt32 returns an OptionalInt32 containing v.
+func OfInt32(v int32) OptionalInt32 {
+	return OptionalInt32{value: v, present: true}
+}
+
+// FromInt32 converts a generic Optional into an OptionalInt32.
+func FromInt32(o optional.Optional[int32]) OptionalInt32 {
+	if o.IsEmpty() {
+		return OptionalInt32{}
+	}
+	return OfInt32(o.Get())
+}
+
+// Optional converts o into a generic Optional.
+func (o OptionalInt32) Optional() optional.Optional[int32] {
+	if !o.present {
+		return optional.Empty[int32]()
+	}
+	return optional.Of(o.value)
+}
+
+// IsPresent returns true if o contains a value.
+func (o OptionalInt32) IsPresent() bool {
+	return o.present
+}
+
+// IsEmpty returns true if o does not contain a value.
+func (o OptionalInt32) IsEmpty() bool {
+	return !o.present
+}
+
+// Get returns the value if present, otherwise it panics.
+func (o OptionalInt32) Get() int32 {
+	if !o.present {
+		panic("OptionalInt32.Get: no value present")
+	}
+	return o.value
+}
+
+// OrElse returns the value if present, otherwise returns other.
+func (o OptionalInt32) OrElse(other int32) int32 {
+	if o.present {
+		return o.value
+	}
+	return other
+}
+
+// String returns a string representation of o.
+func (o OptionalInt32) String() string {
+	if o.present {
+		return fmt.Sprintf("Optional[%v]", o.value)
+	}
+	return "Optional.empty"
+}
+
+// MarshalJSON encodes the value, or null when o is empty.
+func (o OptionalInt32) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value into o, leaving it empty for null.
+func (o *OptionalInt32) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = OptionalInt32{}
+		return nil
+	}
+	var v int32
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = OfInt32(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, leaving o empty for NULL.
+func (o *OptionalInt32) Scan(src any) error {
+	var n sql.Null[int32]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = OptionalInt32{value: n.V, present: n.Valid}
+	return nil
+}
+
+// Value implements driver.Valuer, returning NULL when o is empty.
+func (o OptionalInt32) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// OptionalInt64 is a concrete form of optional.Optional[int64].
+type OptionalInt64 struct {
+	value   int64
+	present bool
+}
+
+// OfInt64 returns an OptionalInt64 containing v.
+func OfInt64(v int64) OptionalInt64 {
+	return OptionalInt64{value: v, present: true}
+}
+
+// FromInt64 converts a generic Optional into an OptionalInt64.
+func FromInt64(o optional.Optional[int64]) OptionalInt64 {
+	if o.IsEmpty() {
+		return OptionalInt64{}
+	}
+	return OfInt64(o.Get())
+}
+
+// Optional converts o into a generic Optional.
+func (o OptionalInt64) Optional() optional.Optional[int64] {
+	if !o.present {
+		return optional.Empty[int64]()
+	}
+	return optional.Of(o.value)
+}
+
+// IsPresent returns true if o contains a value.
+func (o OptionalInt64) IsPresent() bool {
+	return o.present
+}
+
+// IsEmpty returns true if o does not contain a value.
+func (o OptionalInt64) IsEmpty() bool {
+	return !o.present
+}
+
+// Get returns the value if present, otherwise it panics.
+func (o OptionalInt64) Get() int64 {
+	if !o.present {
+		panic("OptionalInt64.Get: no value present")
+	}
+	return o.value
+}
+
+// OrElse returns the value if present, otherwise returns other.
+func (o OptionalInt64) OrElse(other int64) int64 {
+	if o.present {
+		return o.value
+	}
+	return other
+}
+
+// String returns a string representation of o.
+func (o OptionalInt64) String() string {
+	if o.present {
+		return fmt.Sprintf("Optional[%v]", o.value)
+	}
+	return "Optional.empty"
+}
+
+// MarshalJSON encodes the value, or null when o is empty.
+func (o OptionalInt64) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value into o, leaving it empty for null.
+func (o *OptionalInt64) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = OptionalInt64{}
+		return nil
+	}
+	var v int64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = OfInt64(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, leaving o empty for NULL.
+func (o *OptionalInt64) Scan(src any) error {
+	var n sql.Null[int64]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = OptionalInt64{value: n.V, present: n.Valid}
+	return nil
+}
+
+// Value implements driver.Valuer, returning NULL when o is empty.
+func (o OptionalInt64) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// OptionalFloat32 is a concrete form of optional.Optional[float32].
+type OptionalFloat32 struct {
+	value   float32
+	present bool
+}
+
+// OfFloat32 returns an OptionalFloat32 containing v.
+func OfFloat32(v float32) OptionalFloat32 {
+	return OptionalFloat32{value: v, present: true}
+}
+
+// FromFloat32 converts a generic Optional into an OptionalFloat32.
+func FromFloat32(o optional.Optional[float32]) OptionalFloat32 {
+	if o.IsEmpty() {
+		return OptionalFloat32{}
+	}
+	return OfFloat32(o.Get())
+}
+
+// Optional converts o into a generic Optional.
+func (o OptionalFloat32) Optional() optional.Optional[float32] {
+	if !o.present {
+		return optional.Empty[float32]()
+	}
+	return optional.Of(o.value)
+}
+
+// IsPresent returns true if o contains a value.
+func (o OptionalFloat32) IsPresent() bool {
+	return o.present
+}
+
+// IsEmpty returns true if o does not contain a value.
+func (o OptionalFloat32) IsEmpty() bool {
+	return !o.present
+}
+
+// Get returns the value if present, otherwise it panics.
+func (o OptionalFloat32) Get() float32 {
+	if !o.present {
+		panic("OptionalFloat32.Get: no value present")
+	}
+	return o.value
+}
+
+// OrElse returns the value if present, otherwise returns other.
+func (o OptionalFloat32) OrElse(other float32) float32 {
+	if o.present {
+		return o.value
+	}
+	return other
+}
+
+// String returns a string representation of o.
+func (o OptionalFloat32) String() string {
+	if o.present {
+		return fmt.Sprintf("Optional[%v]", o.value)
+	}
+	return "Optional.empty"
+}
+
+// MarshalJSON encodes the value, or null when o is empty.
+func (o OptionalFloat32) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value into o, leaving it empty for null.
+func (o *OptionalFloat32) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = OptionalFloat32{}
+		return nil
+	}
+	var v float32
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = OfFloat32(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, leaving o empty for NULL.
+func (o *OptionalFloat32) Scan(src any) error {
+	var n sql.Null[float32]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = OptionalFloat32{value: n.V, present: n.Valid}
+	return nil
+}
+
+// Value implements driver.Valuer, returning NULL when o is empty.
+func (o OptionalFloat32) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// OptionalFloat64 is a concrete form of optional.Optional[float64].
+type OptionalFloat64 struct {
+	value   float64
+	present bool
+}
+
+// OfFloat64 returns an OptionalFloat64 containing v.
+func OfFloat64(v float64) OptionalFloat64 {
+	return OptionalFloat64{value: v, present: true}
+}
+
+// FromFloat64 converts a generic Optional into an OptionalFloat64.
+func FromFloat64(o optional.Optional[float64]) OptionalFloat64 {
+	if o.IsEmpty() {
+		return OptionalFloat64{}
+	}
+	return OfFloat64(o.Get())
+}
+
+// Optional converts o into a generic Optional.
+func (o OptionalFloat64) Optional() optional.Optional[float64] {
+	if !o.present {
+		return optional.Empty[float64]()
+	}
+	return optional.Of(o.value)
+}
+
+// IsPresent returns true if o contains a value.
+func (o OptionalFloat64) IsPresent() bool {
+	return o.present
+}
+
+// IsEmpty returns true if o does not contain a value.
+func (o OptionalFloat64) IsEmpty() bool {
+	return !o.present
+}
+
+// Get returns the value if present, otherwise it panics.
+func (o OptionalFloat64) Get() float64 {
+	if !o.present {
+		panic("OptionalFloat64.Get: no value present")
+	}
+	return o.value
+}
+
+// OrElse returns the value if present, otherwise returns other.
+func (o OptionalFloat64) OrElse(other float64) float64 {
+	if o.present {
+		return o.value
+	}
+	return other
+}
+
+// String returns a string representation of o.
+func (o OptionalFloat64) String() string {
+	if o.present {
+		return fmt.Sprintf("Optional[%v]", o.value)
+	}
+	return "Optional.empty"
+}
+
+// MarshalJSON encodes the value, or null when o is empty.
+func (o OptionalFloat64) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value into o, leaving it empty for null.
+func (o *OptionalFloat64) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = OptionalFloat64{}
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = OfFloat64(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, leaving o empty for NULL.
+func (o *OptionalFloat64) Scan(src any) error {
+	var n sql.Null[float64]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = OptionalFloat64{value: n.V, present: n.Valid}
+	return nil
+}
+
+// Value implements driver.Valuer, returning NULL when o is empty.
+func (o OptionalFloat64) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// OptionalBool is a concrete form of optional.Optional[bool].
+type OptionalBool struct {
+	value   bool
+	present bool
+}
+
+// OfBool returns an OptionalBool containing v.
+func OfBool(v bool) OptionalBool {
+	return OptionalBool{value: v, present: true}
+}
+
+// FromBool converts a generic Optional into an OptionalBool.
+func FromBool(o optional.Optional[bool]) OptionalBool {
+	if o.IsEmpty() {
+		return OptionalBool{}
+	}
+	return OfBool(o.Get())
+}
+
+// Optional converts o into a generic Optional.
+func (o OptionalBool) Optional() optional.Optional[bool] {
+	if !o.present {
+		return optional.Empty[bool]()
+	}
+	return optional.Of(o.value)
+}
+
+// IsPresent returns true if o contains a value.
+func (o OptionalBool) IsPresent() bool {
+	return o.present
+}
+
+// IsEmpty returns true if o does not contain a value.
+func (o OptionalBool) IsEmpty() bool {
+	return !o.present
+}
+
+// Get returns the value if present, otherwise it panics.
+func (o OptionalBool) Get() bool {
+	if !o.present {
+		panic("OptionalBool.Get: no value present")
+	}
+	return o.value
+}
+
+// OrElse returns the value if present, otherwise returns other.
+func (o OptionalBool) OrElse(other bool) bool {
+	if o.present {
+		return o.value
+	}
+	return other
+}
+
+// String returns a string representation of o.
+func (o OptionalBool) String() string {
+	if o.present {
+		return fmt.Sprintf("Optional[%v]", o.value)
+	}
+	return "Optional.empty"
+}
+
+// MarshalJSON encodes the value, or null when o is empty.
+func (o OptionalBool) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value into o, leaving it empty for null.
+func (o *OptionalBool) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = OptionalBool{}
+		return nil
+	}
+	var v bool
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = OfBool(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, leaving o empty for NULL.
+func (o *OptionalBool) Scan(src any) error {
+	var n sql.Null[bool]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = OptionalBool{value: n.V, present: n.Valid}
+	return nil
+}
+
+// Value implements driver.Valuer, returning NULL when o is empty.
+func (o OptionalBool) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// OptionalByte is a concrete form of optional.Optional[byte].
+type OptionalByte struct {
+	value   byte
+	present bool
+}
+
+// OfByte returns an OptionalByte containing v.
+func OfByte(v byte) OptionalByte {
+	return OptionalByte{value: v, present: true}
+}
+
+// FromByte converts a generic Optional into an OptionalByte.
+func FromByte(o optional.Optional[byte]) OptionalByte {
+	if o.IsEmpty() {
+		return OptionalByte{}
+	}
+	return OfByte(o.Get())
+}
+
+// Optional converts o into a generic Optional.
+func (o OptionalByte) Optional() optional.Optional[byte] {
+	if !o.present {
+		return optional.Empty[byte]()
+	}
+	return optional.Of(o.value)
+}
+
+// IsPresent returns true if o contains a value.
+func (o OptionalByte) IsPresent() bool {
+	return o.present
+}
+
+// IsEmpty returns true if o does not contain a value.
+func (o OptionalByte) IsEmpty() bool {
+	return !o.present
+}
+
+// Get returns the value if present, otherwise it panics.
+func (o OptionalByte) Get() byte {
+	if !o.present {
+		panic("OptionalByte.Get: no value present")
+	}
+	return o.value
+}
+
+// OrElse returns the value if present, otherwise returns other.
+func (o OptionalByte) OrElse(other byte) byte {
+	if o.present {
+		return o.value
+	}
+	return other
+}
+
+// String returns a string representation of o.
+func (o OptionalByte) String() string {
+	if o.present {
+		return fmt.Sprintf("Optional[%v]", o.value)
+	}
+	return "Optional.empty"
+}
+
+// MarshalJSON encodes the value, or null when o is empty.
+func (o OptionalByte) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value into o, leaving it empty for null.
+func (o *OptionalByte) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = OptionalByte{}
+		return nil
+	}
+	var v byte
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = OfByte(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, leaving o empty for NULL.
+func (o *OptionalByte) Scan(src any) error {
+	var n sql.Null[byte]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = OptionalByte{value: n.V, present: n.Valid}
+	return nil
+}
+
+// Value implements driver.Valuer, returning NULL when o is empty.
+func (o OptionalByte) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// OptionalTime is a concrete form of optional.Optional[time.Time].
+type OptionalTime struct {
+	value   time.Time
+	present bool
+}
+
+// OfTime returns an OptionalTime containing v.
+func OfTime(v time.Time) OptionalTime {
+	return OptionalTime{value: v, present: true}
+}
+
+// FromTime converts a generic Optional into an OptionalTime.
+func FromTime(o optional.Optional[time.Time]) OptionalTime {
+	if o.IsEmpty() {
+		return OptionalTime{}
+	}
+	return OfTime(o.Get())
+}
+
+// Optional converts o into a generic Optional.
+func (o OptionalTime) Optional() optional.Optional[time.Time] {
+	if !o.present {
+		return optional.Empty[time.Time]()
+	}
+	return optional.Of(o.value)
+}
+
+// IsPresent returns true if o contains a value.
+func (o OptionalTime) IsPresent() bool {
+	return o.present
+}
+
+// IsEmpty returns true if o does not contain a value.
+func (o OptionalTime) IsEmpty() bool {
+	return !o.present
+}
+
+// Get returns the value if present, otherwise it panics.
+func (o OptionalTime) Get() time.Time {
+	if !o.present {
+		panic("OptionalTime.Get: no value present")
+	}
+	return o.value
+}
+
+// OrElse returns the value if present, otherwise returns other.
+func (o OptionalTime) OrElse(other time.Time) time.Time {
+	if o.present {
+		return o.value
+	}
+	return other
+}
+
+// String returns a string representation of o.
+func (o OptionalTime) String() string {
+	if o.present {
+		return fmt.Sprintf("Optional[%v]", o.value)
+	}
+	return "Optional.empty"
+}
+
+// MarshalJSON encodes the value, or null when o is empty.
+func (o OptionalTime) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value into o, leaving it empty for null.
+func (o *OptionalTime) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = OptionalTime{}
+		return nil
+	}
+	var v time.Time
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = OfTime(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, leaving o empty for NULL.
+func (o *OptionalTime) Scan(src any) error {
+	var n sql.Null[time.Time]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = OptionalTime{value: n.V, present: n.Valid}
+	return nil
+}
+
+// Value implements driver.Valuer, returning NULL when o is empty.
+func (o OptionalTime) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// OptionalDuration is a concrete form of optional.Optional[time.Duration].
+type OptionalDuration struct {
+	value   time.Duration
+	present bool
+}
+
+// OfDuration returns an OptionalDuration containing v.
+func OfDuration(v time.Duration) OptionalDuration {
+	return OptionalDuration{value: v, present: true}
+}
+
+// FromDuration converts a generic Optional into an OptionalDuration.
+func FromDuration(o optional.Optional[time.Duration]) OptionalDuration {
+	if o.IsEmpty() {
+		return OptionalDuration{}
+	}
+	return OfDuration(o.Get())
+}
+
+// Optional converts o into a generic Optional.
+func (o OptionalDuration) Optional() optional.Optional[time.Duration] {
+	if !o.present {
+		return optional.Empty[time.Duration]()
+	}
+	return optional.Of(o.value)
+}
+
+// IsPresent returns true if o contains a value.
+func (o OptionalDuration) IsPresent() bool {
+	return o.present
+}
+
+// IsEmpty returns true if o does not contain a value.
+func (o OptionalDuration) IsEmpty() bool {
+	return !o.present
+}
+
+// Get returns the value if present, otherwise it panics.
+func (o OptionalDuration) Get() time.Duration {
+	if !o.present {
+		panic("OptionalDuration.Get: no value present")
+	}
+	return o.value
+}
+
+// OrElse returns the value if present, otherwise returns other.
+func (o OptionalDuration) OrElse(other time.Duration) time.Duration {
+	if o.present {
+		return o.value
+	}
+	return other
+}
+
+// String returns a string representation of o.
+func (o OptionalDuration) String() string {
+	if o.present {
+		return fmt.Sprintf("Optional[%v]", o.value)
+	}
+	return "Optional.empty"
+}
+
+// MarshalJSON encodes the value, or null when o is empty.
+func (o OptionalDuration) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes a value into o, leaving it empty for null.
+func (o *OptionalDuration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = OptionalDuration{}
+		return nil
+	}
+	var v time.Duration
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = OfDuration(v)
+	return nil
+}
+
+// Scan implements sql.Scanner, leaving o empty for NULL.
+func (o *OptionalDuration) Scan(src any) error {
+	var n sql.Null[time.Duration]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = OptionalDuration{value: n.V, present: n.Valid}
+	return nil
+}
+
+// Value implements driver.Valuer, returning NULL when o is empty.
+func (o OptionalDuration) Value() (driver.Value, error) {
+	if !o.present {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
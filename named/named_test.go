@@ -0,0 +1,90 @@
+package named
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+func TestOptionalStringConversion(t *testing.T) {
+	opt := FromString(optional.Of("hello"))
+	if !opt.IsPresent() || opt.Get() != "hello" {
+		t.Errorf("Expected present value 'hello', but got %v", opt)
+	}
+	if back := opt.Optional(); !back.IsPresent() || back.Get() != "hello" {
+		t.Errorf("Expected round trip to keep 'hello', but got %v", back)
+	}
+
+	empty := FromString(optional.Empty[string]())
+	if empty.IsPresent() || empty.Optional().IsPresent() {
+		t.Errorf("Expected empty conversion to stay empty, but got %v", empty)
+	}
+}
+
+func TestOptionalGetEmpty(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic for empty optional, but did not panic")
+		}
+	}()
+	var empty OptionalInt
+	_ = empty.Get() // Should panic
+}
+
+func TestOptionalString(t *testing.T) {
+	if s := OfInt(42).String(); s != "Optional[42]" {
+		t.Errorf("Expected string 'Optional[42]', but got %s", s)
+	}
+	if s := (OptionalInt{}).String(); s != "Optional.empty" {
+		t.Errorf("Expected string 'Optional.empty', but got %s", s)
+	}
+}
+
+func TestOptionalJSON(t *testing.T) {
+	type payload struct {
+		Name OptionalString `json:"name"`
+		Age  OptionalInt    `json:"age"`
+	}
+	data, err := json.Marshal(payload{Name: OfString("Ada")})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if string(data) != `{"name":"Ada","age":null}` {
+		t.Errorf("Expected JSON with null age, but got %s", data)
+	}
+
+	var p payload
+	if err := json.Unmarshal([]byte(`{"name":null,"age":36}`), &p); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if p.Name.IsPresent() || p.Age.OrElse(0) != 36 {
+		t.Errorf("Expected empty name and age 36, but got %v and %v", p.Name, p.Age)
+	}
+}
+
+func TestOptionalSQL(t *testing.T) {
+	var age OptionalInt
+	if err := age.Scan(int64(36)); err != nil || age.OrElse(0) != 36 {
+		t.Errorf("Expected scanned value 36, but got %v (err %v)", age, err)
+	}
+	if err := age.Scan(nil); err != nil || age.IsPresent() {
+		t.Errorf("Expected NULL to scan as empty, but got %v (err %v)", age, err)
+	}
+
+	v, err := OfInt(7).Value()
+	if err != nil || v != driver.Value(int64(7)) {
+		t.Errorf("Expected driver value int64(7), but got %#v (err %v)", v, err)
+	}
+	if v, _ := (OptionalTime{}).Value(); v != nil {
+		t.Errorf("Expected nil driver value for empty optional, but got %#v", v)
+	}
+
+	now := time.Now()
+	var ts OptionalTime
+	if err := ts.Scan(now); err != nil || !ts.Get().Equal(now) {
+		t.Errorf("Expected scanned time %v, but got %v (err %v)", now, ts, err)
+	}
+}
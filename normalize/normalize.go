@@ -0,0 +1,28 @@
+// Package normalize provides string normalizers for use with
+// Optional.Normalize:
+//
+//	email = email.Normalize(normalize.TrimSpace, normalize.ToLower)
+package normalize
+
+import "strings"
+
+// TrimSpace removes leading and trailing white space.
+func TrimSpace[T ~string](s T) T {
+	return T(strings.TrimSpace(string(s)))
+}
+
+// ToLower maps all letters to lower case.
+func ToLower[T ~string](s T) T {
+	return T(strings.ToLower(string(s)))
+}
+
+// ToUpper maps all letters to upper case.
+func ToUpper[T ~string](s T) T {
+	return T(strings.ToUpper(string(s)))
+}
+
+// CollapseSpace trims s and replaces each run of white space within it by a
+// single space.
+func CollapseSpace[T ~string](s T) T {
+	return T(strings.Join(strings.Fields(string(s)), " "))
+}
@@ -0,0 +1,35 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+type email string
+
+func TestNormalizers(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(string) string
+		in   string
+		want string
+	}{
+		{"TrimSpace", TrimSpace[string], "  a b \n", "a b"},
+		{"ToLower", ToLower[string], "AbC", "abc"},
+		{"ToUpper", ToUpper[string], "AbC", "ABC"},
+		{"CollapseSpace", CollapseSpace[string], " a \t b\n\nc ", "a b c"},
+	}
+	for _, tt := range tests {
+		if got := tt.fn(tt.in); got != tt.want {
+			t.Errorf("%s: Expected %q, but got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestWithNormalize(t *testing.T) {
+	got := optional.Of(email(" Alice@Example.COM ")).Normalize(TrimSpace, ToLower)
+	if got.OrElse("") != "alice@example.com" {
+		t.Errorf("Expected alice@example.com, but got %v", got)
+	}
+}
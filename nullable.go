@@ -0,0 +1,119 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Nullable is a tri-state value distinguishing a value that was not provided
+// at all from one explicitly set to null, as JSON merge patches require. The
+// zero Nullable is unset.
+type Nullable[T any] struct {
+	set   bool
+	value Optional[T]
+}
+
+// Unset creates a Nullable that was not provided.
+func Unset[T any]() Nullable[T] {
+	return Nullable[T]{}
+}
+
+// Null creates a Nullable explicitly set to null.
+func Null[T any]() Nullable[T] {
+	return Nullable[T]{set: true, value: Empty[T]()}
+}
+
+// NullableOf creates a Nullable set to the given value.
+func NullableOf[T any](value T) Nullable[T] {
+	return Nullable[T]{set: true, value: Of(value)}
+}
+
+// IsSet returns true if the Nullable was provided, either as null or as a value.
+func (n Nullable[T]) IsSet() bool {
+	return n.set
+}
+
+// IsNull returns true if the Nullable was explicitly set to null.
+func (n Nullable[T]) IsNull() bool {
+	return n.set && n.value.IsEmpty()
+}
+
+// Optional returns the value as an Optional, empty when unset or null.
+func (n Nullable[T]) Optional() Optional[T] {
+	if !n.set {
+		return Empty[T]()
+	}
+	return n.value
+}
+
+// String returns a string representation of the Nullable.
+func (n Nullable[T]) String() string {
+	switch {
+	case !n.set:
+		return "Nullable.unset"
+	case n.value.IsEmpty():
+		return "Nullable.null"
+	}
+	return fmt.Sprintf("Nullable[%v]", *n.value.value)
+}
+
+// MarshalJSON encodes the value, or null when unset or null. Tag the field
+// omitzero to leave unset values out entirely.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	return n.value.MarshalJSON()
+}
+
+// UnmarshalJSON marks the Nullable as set and decodes data into it. It is
+// only called for keys present in the input, so absent keys stay unset.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = Null[T]()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*n = Nullable[T]{set: true, value: Optional[T]{value: &value}}
+	return nil
+}
+
+// IsZero returns true if the Nullable is unset.
+func (n Nullable[T]) IsZero() bool {
+	return !n.set
+}
+
+// reflectedNullable is implemented by every Nullable.
+type reflectedNullable interface {
+	nullableValue() (set bool, value reflect.Value, present bool)
+	nullableElem() reflect.Type
+}
+
+// nullableSetter is implemented by every *Nullable.
+type nullableSetter interface {
+	setNullable(v reflect.Value)
+}
+
+var reflectedNullableType = reflect.TypeFor[reflectedNullable]()
+
+func (n Nullable[T]) nullableValue() (bool, reflect.Value, bool) {
+	v, present := n.value.reflectValue()
+	return n.set, v, present
+}
+
+func (n Nullable[T]) nullableElem() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// setNullable sets n to v, or to null when v is invalid.
+func (n *Nullable[T]) setNullable(v reflect.Value) {
+	n.set = true
+	n.value.setReflect(v)
+}
+
+// isNullableType reports whether t is a Nullable, or a type embedding one.
+func isNullableType(t reflect.Type) bool {
+	return t.Implements(reflectedNullableType)
+}
@@ -0,0 +1,80 @@
+package optional
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullableStates(t *testing.T) {
+	unset, null, value := Unset[int](), Null[int](), NullableOf(42)
+	if unset.IsSet() || unset.IsNull() || unset.Optional().IsPresent() {
+		t.Errorf("Expected unset Nullable, but got %v", unset)
+	}
+	if !null.IsSet() || !null.IsNull() || null.Optional().IsPresent() {
+		t.Errorf("Expected null Nullable, but got %v", null)
+	}
+	if !value.IsSet() || value.IsNull() || value.Optional().OrElse(0) != 42 {
+		t.Errorf("Expected Nullable holding 42, but got %v", value)
+	}
+	for n, want := range map[Nullable[int]]string{unset: "Nullable.unset", null: "Nullable.null", value: "Nullable[42]"} {
+		if n.String() != want {
+			t.Errorf("Expected string %q, but got %q", want, n.String())
+		}
+	}
+}
+
+func TestNullableJSON(t *testing.T) {
+	var v struct {
+		A Nullable[string] `json:"a,omitzero"`
+		B Nullable[string] `json:"b,omitzero"`
+		C Nullable[string] `json:"c,omitzero"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":null,"b":"x"}`), &v); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !v.A.IsNull() || v.B.Optional().OrElse("") != "x" || v.C.IsSet() {
+		t.Errorf("Expected null, value and unset, but got %v, %v, %v", v.A, v.B, v.C)
+	}
+	data, err := json.Marshal(v)
+	if err != nil || string(data) != `{"a":null,"b":"x"}` {
+		t.Errorf("Expected round trip to omit unset field, but got %s (err %v)", data, err)
+	}
+}
+
+func TestApplyPatchNullable(t *testing.T) {
+	email := "ada@example.com"
+	user := patchUser{Name: "Ada", Email: &email, Age: Of(36)}
+	patch := struct {
+		Name  Nullable[string]
+		Email Nullable[string]
+		Age   Nullable[int]
+	}{Name: NullableOf("Grace"), Email: Null[string]()}
+
+	changed, err := ApplyPatchChanges(&user, patch)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if user.Name != "Grace" || user.Email != nil || user.Age.OrElse(0) != 36 {
+		t.Errorf("Expected name Grace, nil email and age 36, but got %+v", user)
+	}
+	if len(changed) != 2 || changed[0] != "Name" || changed[1] != "Email" {
+		t.Errorf("Expected changed fields [Name Email], but got %v", changed)
+	}
+}
+
+func TestDiffNullable(t *testing.T) {
+	email := "ada@example.com"
+	before := patchUser{Name: "Ada", Email: &email}
+	after := patchUser{Name: "Grace"}
+	patch, err := Diff[struct {
+		Name  Nullable[string]
+		Email Nullable[string]
+		Age   Nullable[int]
+	}](before, after)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if patch.Name.Optional().OrElse("") != "Grace" || !patch.Email.IsNull() || patch.Age.IsSet() {
+		t.Errorf("Expected Grace, null and unset, but got %v, %v, %v", patch.Name, patch.Email, patch.Age)
+	}
+}
@@ -0,0 +1,32 @@
+// Package nullopt converts between Optional and the types of
+// github.com/guregu/null, such as null.String, null.Int and null.Time, at
+// the repository boundaries of codebases that use both.
+//
+// The package relies only on the Ptr method and FromPtr constructors every
+// null type provides, so it does not import the library itself:
+//
+//	name := nullopt.From[string](row.Name)
+//	row.Name = nullopt.To(name, null.StringFromPtr)
+package nullopt
+
+import "github.com/hermann-craft/optional"
+
+// Value is the method set shared by the guregu/null types holding a T.
+type Value[T any] interface {
+	Ptr() *T
+}
+
+// From converts a guregu/null value holding a T into an Optional.
+func From[T any, N Value[T]](n N) optional.Optional[T] {
+	return optional.OfNullable(n.Ptr())
+}
+
+// To converts an Optional into a guregu/null value using the library's
+// FromPtr constructor for it, such as null.StringFromPtr.
+func To[N, T any](o optional.Optional[T], fromPtr func(*T) N) N {
+	if o.IsEmpty() {
+		return fromPtr(nil)
+	}
+	v := o.Get()
+	return fromPtr(&v)
+}
@@ -0,0 +1,45 @@
+package nullopt
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+// nullString mirrors null.String from guregu/null.
+type nullString struct {
+	sql.NullString
+}
+
+func stringFromPtr(s *string) nullString {
+	if s == nil {
+		return nullString{}
+	}
+	return nullString{sql.NullString{String: *s, Valid: true}}
+}
+
+func (s nullString) Ptr() *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+func TestFrom(t *testing.T) {
+	if got := From[string](stringFromPtr(new(string))); !got.IsPresent() || got.Get() != "" {
+		t.Errorf("Expected Optional[], but got %v", got)
+	}
+	if got := From[string](nullString{}); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestTo(t *testing.T) {
+	if got := To(optional.Of("a"), stringFromPtr); !got.Valid || got.String != "a" {
+		t.Errorf("Expected a valid a, but got %v", got)
+	}
+	if got := To(optional.Empty[string](), stringFromPtr); got.Valid {
+		t.Errorf("Expected an invalid value, but got %v", got)
+	}
+}
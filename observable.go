@@ -0,0 +1,94 @@
+package optional
+
+import "sync"
+
+// Observable holds an optional value and notifies subscribers when it is set
+// or cleared, which suits configuration values and feature flags that other
+// components depend on. The zero Observable is empty and ready to use; it is
+// safe for concurrent use and must not be copied after first use.
+type Observable[T any] struct {
+	mu      sync.Mutex
+	value   Optional[T]
+	next    int
+	onSet   map[int]func(T)
+	onClear map[int]func()
+}
+
+// Optional returns the current value.
+func (o *Observable[T]) Optional() Optional[T] {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.value
+}
+
+// Set stores v and calls every OnSet subscriber with it.
+func (o *Observable[T]) Set(v T) {
+	// Of may panic on a nil v, which must not leave o locked.
+	value := Of(v)
+	o.mu.Lock()
+	o.value = value
+	listeners := make([]func(T), 0, len(o.onSet))
+	for _, fn := range o.onSet {
+		listeners = append(listeners, fn)
+	}
+	o.mu.Unlock()
+	for _, fn := range listeners {
+		fn(v)
+	}
+}
+
+// Clear empties the Observable and, if it held a value, calls every OnClear
+// subscriber.
+func (o *Observable[T]) Clear() {
+	o.mu.Lock()
+	wasPresent := o.value.IsPresent()
+	o.value = Empty[T]()
+	listeners := make([]func(), 0, len(o.onClear))
+	if wasPresent {
+		for _, fn := range o.onClear {
+			listeners = append(listeners, fn)
+		}
+	}
+	o.mu.Unlock()
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+// OnSet subscribes fn to every value set, whether it appears or changes, and
+// returns a function that cancels the subscription. Subscribers are called
+// synchronously, in no particular order, by the goroutine calling Set.
+func (o *Observable[T]) OnSet(fn func(T)) (cancel func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.onSet == nil {
+		o.onSet = map[int]func(T){}
+	}
+	id := o.subscribe()
+	o.onSet[id] = fn
+	return func() { o.unsubscribe(func() { delete(o.onSet, id) }) }
+}
+
+// OnClear subscribes fn to the value disappearing and returns a function
+// that cancels the subscription.
+func (o *Observable[T]) OnClear(fn func()) (cancel func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.onClear == nil {
+		o.onClear = map[int]func(){}
+	}
+	id := o.subscribe()
+	o.onClear[id] = fn
+	return func() { o.unsubscribe(func() { delete(o.onClear, id) }) }
+}
+
+func (o *Observable[T]) subscribe() int {
+	o.next++
+	return o.next
+}
+
+func (o *Observable[T]) unsubscribe(remove func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	remove()
+}
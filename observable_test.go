@@ -0,0 +1,77 @@
+package optional
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestObservable(t *testing.T) {
+	var o Observable[string]
+	var set []string
+	clears := 0
+	cancelSet := o.OnSet(func(v string) { set = append(set, v) })
+	o.OnClear(func() { clears++ })
+
+	if o.Optional().IsPresent() {
+		t.Errorf("Expected the zero Observable to be empty, but got %v", o.Optional())
+	}
+	o.Set("a")
+	o.Set("b")
+	o.Clear()
+	o.Clear()
+	if len(set) != 2 || set[0] != "a" || set[1] != "b" {
+		t.Errorf("Expected [a b], but got %v", set)
+	}
+	if clears != 1 {
+		t.Errorf("Expected 1 clear notification, but got %d", clears)
+	}
+
+	cancelSet()
+	o.Set("c")
+	if len(set) != 2 || o.Optional().Get() != "c" {
+		t.Errorf("Expected no notification after cancel and value c, but got %v and %v", set, o.Optional())
+	}
+}
+
+func TestObservableConcurrent(t *testing.T) {
+	var o Observable[int]
+	var mu sync.Mutex
+	total := 0
+	o.OnSet(func(v int) {
+		mu.Lock()
+		total += v
+		mu.Unlock()
+	})
+	var wg sync.WaitGroup
+	for i := 1; i <= 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.Set(i)
+			_ = o.Optional()
+		}()
+	}
+	wg.Wait()
+	if total != 5050 {
+		t.Errorf("Expected 5050, but got %d", total)
+	}
+}
+
+func TestObservableSetNil(t *testing.T) {
+	SetNilPolicy(ErrorOnNil)
+	defer SetNilPolicy(PanicOnNil)
+	var o Observable[*int]
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Expected a panic for a nil value")
+			}
+		}()
+		o.Set(nil)
+	}()
+	n := 1
+	o.Set(&n)
+	if o.Optional().Get() != &n {
+		t.Errorf("Expected the Observable to be usable after the panic, but got %v", o.Optional())
+	}
+}
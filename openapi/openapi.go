@@ -0,0 +1,78 @@
+// Package openapi helps OpenAPI and JSON Schema generators describe
+// Optional fields the way they marshal: as the schema of the held value,
+// nullable, and not required.
+//
+// The helpers work on reflect types, so they plug into the interception
+// hooks generators provide, such as the interceptors of
+// github.com/swaggest/jsonschema-go or the schema customizers of
+// kin-openapi: ElemType gives the type to describe in place of an Optional,
+// IsNullable whether to mark a property nullable, and RequiredFields the
+// required list of a struct schema.
+package openapi
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/hermann-craft/optional/reflectopt"
+)
+
+// ElemType returns the type of the value held by an Optional type, or false
+// if t is not an Optional, as recognised by reflectopt.
+func ElemType(t reflect.Type) (reflect.Type, bool) {
+	elem := reflectopt.ElemType(t)
+	return elem, elem != nil
+}
+
+// IsNullable reports whether the struct field f should be documented as
+// nullable: it is an Optional or a pointer.
+func IsNullable(f reflect.StructField) bool {
+	_, ok := ElemType(f.Type)
+	return ok || f.Type.Kind() == reflect.Pointer
+}
+
+// RequiredFields returns the JSON names of the fields of the struct type t
+// that are always present in its JSON encoding: the exported fields that
+// are neither Optionals nor pointers and are not tagged omitempty or
+// omitzero, together with any field tagged `optional:"required"`. Fields of
+// embedded structs are included.
+func RequiredFields(t reflect.Type) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup("json")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if f.Anonymous && !hasTag && f.Type.Kind() == reflect.Struct {
+			names = append(names, RequiredFields(f.Type)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		switch {
+		case hasOption(f.Tag.Get("optional"), "required"):
+		case IsNullable(f), hasOption(opts, "omitempty"), hasOption(opts, "omitzero"):
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func hasOption(list, option string) bool {
+	for _, o := range strings.Split(list, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+type base struct {
+	ID int64 `json:"id"`
+}
+
+type user struct {
+	base
+	Name     string                       `json:"name"`
+	Nickname optional.Optional[string]    `json:"nickname"`
+	Email    optional.Optional[string]    `json:"email" optional:"required"`
+	Born     optional.Optional[time.Time] `json:"born,omitzero"`
+	Manager  *user                        `json:"manager"`
+	Note     string                       `json:"note,omitempty"`
+	Secret   string                       `json:"-"`
+	Plain    bool
+	hidden   int
+}
+
+func TestElemType(t *testing.T) {
+	if elem, ok := ElemType(reflect.TypeFor[optional.Optional[time.Time]]()); !ok || elem != reflect.TypeFor[time.Time]() {
+		t.Errorf("Expected time.Time, but got %v", elem)
+	}
+	if _, ok := ElemType(reflect.TypeFor[string]()); ok {
+		t.Error("Expected string not to be an Optional")
+	}
+}
+
+func TestIsNullable(t *testing.T) {
+	typ := reflect.TypeFor[user]()
+	for name, want := range map[string]bool{"Name": false, "Nickname": true, "Manager": true} {
+		f, _ := typ.FieldByName(name)
+		if got := IsNullable(f); got != want {
+			t.Errorf("Expected %v for %s, but got %v", want, name, got)
+		}
+	}
+}
+
+func TestRequiredFields(t *testing.T) {
+	got := RequiredFields(reflect.TypeFor[*user]())
+	want := []string{"id", "name", "email", "Plain"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, but got %v", want, got)
+	}
+}
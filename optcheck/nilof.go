@@ -0,0 +1,177 @@
+package optcheck
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// NilOfAnalyzer reports calls to Of whose pointer or interface argument is
+// nil on some path. A nil pointer, or an interface holding one, makes Of
+// panic, and a nil interface gives a present Optional holding nil; OfNullable
+// should be used for pointers and OfNilable for interfaces instead. It also
+// reports calls to Of with an unchecked error, which would make a nil error
+// a present value; OfErr should be used instead.
+var NilOfAnalyzer = &analysis.Analyzer{
+	Name:     "optnilof",
+	Doc:      "report calls to Of with a pointer or interface argument that may be nil",
+	URL:      "https://pkg.go.dev/github.com/hermann-craft/optional/optcheck",
+	Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	Run:      runNilOf,
+}
+
+func runNilOf(pass *analysis.Pass) (any, error) {
+	if pass.Pkg.Path() == optionalPath {
+		return nil, nil
+	}
+	input := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+	for _, fn := range input.SrcFuncs {
+		checkNilOf(pass, fn)
+	}
+	return nil, nil
+}
+
+func checkNilOf(pass *analysis.Pass, fn *ssa.Function) {
+	var guards []guard
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok || !isOptionalFunc(call.Common(), "Of") || len(call.Common().Args) != 1 {
+				continue
+			}
+			arg := call.Common().Args[0]
+			if !isNilable(arg.Type()) {
+				continue
+			}
+			if guards == nil {
+				guards = collectNilGuards(fn)
+			}
+			if isGuarded(guards, arg, b) || isGuarded(guards, unconvert(arg), b) {
+				continue
+			}
+			switch {
+			case isNilConst(arg):
+				pass.Reportf(call.Pos(), "Of called with nil; use Empty instead")
+			case types.Identical(arg.Type(), errorType):
+				pass.Reportf(call.Pos(), "Of called with an error that may be nil; use OfErr instead")
+			case mayBeNil(arg, map[ssa.Value]bool{}):
+				if types.IsInterface(arg.Type()) {
+					pass.Reportf(call.Pos(), "argument to Of may be nil; use OfNilable instead")
+				} else {
+					pass.Reportf(call.Pos(), "argument to Of may be nil; use OfNullable instead")
+				}
+			}
+		}
+	}
+}
+
+// collectNilGuards finds every comparison of a value against nil in fn and
+// records the successor block in which the value is known to be non-nil.
+func collectNilGuards(fn *ssa.Function) []guard {
+	guards := []guard{}
+	for _, b := range fn.Blocks {
+		if len(b.Instrs) == 0 {
+			continue
+		}
+		ifInstr, ok := b.Instrs[len(b.Instrs)-1].(*ssa.If)
+		if !ok {
+			continue
+		}
+		cmp, ok := ifInstr.Cond.(*ssa.BinOp)
+		if !ok || (cmp.Op != token.EQL && cmp.Op != token.NEQ) {
+			continue
+		}
+		v := cmp.X
+		if isNilConst(v) {
+			v = cmp.Y
+		} else if !isNilConst(cmp.Y) {
+			continue
+		}
+		succ := b.Succs[0]
+		if cmp.Op == token.EQL {
+			succ = b.Succs[1]
+		}
+		if len(succ.Preds) != 1 {
+			continue
+		}
+		guards = append(guards, guard{recv: v, block: succ})
+	}
+	return guards
+}
+
+// mayBeNil reports whether a nil constant can flow into v along some path.
+func mayBeNil(v ssa.Value, seen map[ssa.Value]bool) bool {
+	if seen[v] {
+		return false
+	}
+	seen[v] = true
+	switch v := v.(type) {
+	case *ssa.Const:
+		return v.IsNil()
+	case *ssa.Phi:
+		for _, edge := range v.Edges {
+			if mayBeNil(edge, seen) {
+				return true
+			}
+		}
+	case *ssa.MakeInterface:
+		return isNilable(v.X.Type()) && mayBeNil(v.X, seen)
+	case *ssa.ChangeType:
+		return mayBeNil(v.X, seen)
+	case *ssa.ChangeInterface:
+		return mayBeNil(v.X, seen)
+	}
+	return false
+}
+
+// unconvert strips the interface and type conversions wrapping v.
+func unconvert(v ssa.Value) ssa.Value {
+	for {
+		switch x := v.(type) {
+		case *ssa.MakeInterface:
+			v = x.X
+		case *ssa.ChangeType:
+			v = x.X
+		case *ssa.ChangeInterface:
+			v = x.X
+		default:
+			return v
+		}
+	}
+}
+
+// isOptionalFunc reports whether common is a static call to the named
+// package-level function of the optional package.
+func isOptionalFunc(common *ssa.CallCommon, name string) bool {
+	callee := common.StaticCallee()
+	if callee == nil || callee.Signature.Recv() != nil {
+		return false
+	}
+	if origin := callee.Origin(); origin != nil {
+		callee = origin
+	}
+	return callee.Pkg != nil && callee.Pkg.Pkg.Path() == optionalPath && callee.Name() == name
+}
+
+// isNilable reports whether values of type t may be nil pointers or nil
+// interfaces.
+func isNilable(t types.Type) bool {
+	if _, ok := types.Unalias(t).(*types.TypeParam); ok {
+		return false
+	}
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Interface:
+		return true
+	}
+	return false
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+func isNilConst(v ssa.Value) bool {
+	c, ok := v.(*ssa.Const)
+	return ok && c.IsNil()
+}
@@ -0,0 +1,168 @@
+// Package optcheck defines analyzers that report panic-prone uses of the
+// optional package.
+package optcheck
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+const optionalPath = "github.com/hermann-craft/optional"
+
+// Analyzer reports calls to Get and OrElseThrow on an Optional that are not
+// dominated by an IsPresent (or negated IsEmpty) check on the same Optional.
+var Analyzer = &analysis.Analyzer{
+	Name:     "optcheck",
+	Doc:      "report Get and OrElseThrow calls on Optionals not guarded by an IsPresent check",
+	URL:      "https://pkg.go.dev/github.com/hermann-craft/optional/optcheck",
+	Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	Run:      run,
+}
+
+// guard records that the optional recv is known to be present in every
+// block dominated by block.
+type guard struct {
+	recv  ssa.Value
+	block *ssa.BasicBlock
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if pass.Pkg.Path() == optionalPath {
+		return nil, nil
+	}
+	input := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+	for _, fn := range input.SrcFuncs {
+		checkFunc(pass, fn)
+	}
+	return nil, nil
+}
+
+func checkFunc(pass *analysis.Pass, fn *ssa.Function) {
+	guards := collectGuards(fn)
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok {
+				continue
+			}
+			name, recv, ok := optionalMethodCall(call.Common())
+			if !ok || (name != "Get" && name != "OrElseThrow") {
+				continue
+			}
+			if !isGuarded(guards, recv, b) {
+				pass.Reportf(call.Pos(), "call to %s on an Optional that may be empty; check IsPresent first", name)
+			}
+		}
+	}
+}
+
+// collectGuards finds every branch on IsPresent or IsEmpty in fn and records
+// the successor block in which the Optional is known to be present.
+func collectGuards(fn *ssa.Function) []guard {
+	var guards []guard
+	for _, b := range fn.Blocks {
+		if len(b.Instrs) == 0 {
+			continue
+		}
+		ifInstr, ok := b.Instrs[len(b.Instrs)-1].(*ssa.If)
+		if !ok {
+			continue
+		}
+		cond, present := ifInstr.Cond, true
+		for {
+			not, ok := cond.(*ssa.UnOp)
+			if !ok || not.Op != token.NOT {
+				break
+			}
+			cond, present = not.X, !present
+		}
+		call, ok := cond.(*ssa.Call)
+		if !ok {
+			continue
+		}
+		name, recv, ok := optionalMethodCall(call.Common())
+		if !ok {
+			continue
+		}
+		switch name {
+		case "IsPresent":
+		case "IsEmpty":
+			present = !present
+		default:
+			continue
+		}
+		succ := b.Succs[1]
+		if present {
+			succ = b.Succs[0]
+		}
+		// A successor reachable from elsewhere tells us nothing about the
+		// outcome of this particular check.
+		if len(succ.Preds) != 1 {
+			continue
+		}
+		guards = append(guards, guard{recv: recv, block: succ})
+	}
+	return guards
+}
+
+func isGuarded(guards []guard, recv ssa.Value, b *ssa.BasicBlock) bool {
+	for _, g := range guards {
+		if g.block.Dominates(b) && sameValue(g.recv, recv) {
+			return true
+		}
+	}
+	return false
+}
+
+// optionalMethodCall reports the method name and receiver of a static call
+// to a method of optional.Optional.
+func optionalMethodCall(common *ssa.CallCommon) (string, ssa.Value, bool) {
+	callee := common.StaticCallee()
+	if callee == nil || callee.Signature.Recv() == nil || len(common.Args) == 0 {
+		return "", nil, false
+	}
+	if !isOptional(callee.Signature.Recv().Type()) {
+		return "", nil, false
+	}
+	if origin := callee.Origin(); origin != nil {
+		callee = origin
+	}
+	return callee.Name(), common.Args[0], true
+}
+
+// isOptional reports whether t is optional.Optional[T] or a pointer to it.
+func isOptional(t types.Type) bool {
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Origin().Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == optionalPath && obj.Name() == "Optional"
+}
+
+// sameValue reports whether a and b denote the same Optional. Loads from the
+// same variable or struct field are treated as the same value.
+func sameValue(a, b ssa.Value) bool {
+	if a == b {
+		return true
+	}
+	switch a := a.(type) {
+	case *ssa.UnOp:
+		b, ok := b.(*ssa.UnOp)
+		return ok && a.Op == token.MUL && b.Op == token.MUL && sameValue(a.X, b.X)
+	case *ssa.FieldAddr:
+		b, ok := b.(*ssa.FieldAddr)
+		return ok && a.Field == b.Field && sameValue(a.X, b.X)
+	case *ssa.Field:
+		b, ok := b.(*ssa.Field)
+		return ok && a.Field == b.Field && sameValue(a.X, b.X)
+	}
+	return false
+}
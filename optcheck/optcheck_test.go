@@ -0,0 +1,16 @@
+package optcheck_test
+
+import (
+	"testing"
+
+	"github.com/hermann-craft/optional/optcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), optcheck.Analyzer, "a")
+}
+
+func TestNilOfAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), optcheck.NilOfAnalyzer, "b")
+}
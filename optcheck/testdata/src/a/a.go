@@ -0,0 +1,81 @@
+package a
+
+import (
+	"errors"
+
+	"github.com/hermann-craft/optional"
+)
+
+type holder struct {
+	opt optional.Optional[int]
+}
+
+func unchecked(o optional.Optional[int]) int {
+	return o.Get() // want `call to Get on an Optional that may be empty`
+}
+
+func uncheckedThrow(o optional.Optional[int]) int {
+	return o.OrElseThrow(errors.New("missing")) // want `call to OrElseThrow on an Optional that may be empty`
+}
+
+func checked(o optional.Optional[int]) int {
+	if o.IsPresent() {
+		return o.Get()
+	}
+	return 0
+}
+
+func earlyReturn(o optional.Optional[int]) int {
+	if o.IsEmpty() {
+		return 0
+	}
+	return o.Get()
+}
+
+func negated(o optional.Optional[int]) int {
+	if !o.IsPresent() {
+		return 0
+	}
+	return o.Get()
+}
+
+func shortCircuit(o optional.Optional[int]) bool {
+	return o.IsPresent() && o.Get() > 0
+}
+
+func wrongBranch(o optional.Optional[int]) int {
+	if o.IsEmpty() {
+		return o.Get() // want `call to Get on an Optional that may be empty`
+	}
+	return 0
+}
+
+func fallthroughCheck(o optional.Optional[int]) int {
+	if o.IsEmpty() {
+		println("empty")
+	}
+	return o.Get() // want `call to Get on an Optional that may be empty`
+}
+
+func otherOptional(a, b optional.Optional[int]) int {
+	if a.IsPresent() {
+		return b.Get() // want `call to Get on an Optional that may be empty`
+	}
+	return 0
+}
+
+func field(h *holder) int {
+	if h.opt.IsPresent() {
+		return h.opt.Get()
+	}
+	return 0
+}
+
+func local() int {
+	o := optional.Of(1)
+	p := &o
+	if p.IsPresent() {
+		return p.Get()
+	}
+	return 0
+}
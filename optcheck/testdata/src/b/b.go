@@ -0,0 +1,108 @@
+package b
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hermann-craft/optional"
+)
+
+type user struct{ name string }
+
+func lookup(id int) *user {
+	if id == 0 {
+		return nil
+	}
+	return &user{}
+}
+
+func literalNil() optional.Optional[*user] {
+	return optional.Of[*user](nil) // want `Of called with nil; use Empty instead`
+}
+
+func conditional(ok bool) optional.Optional[*user] {
+	var u *user
+	if ok {
+		u = &user{}
+	}
+	return optional.Of(u) // want `argument to Of may be nil; use OfNullable instead`
+}
+
+func guarded(ok bool) optional.Optional[*user] {
+	var u *user
+	if ok {
+		u = &user{}
+	}
+	if u != nil {
+		return optional.Of(u)
+	}
+	return optional.Empty[*user]()
+}
+
+func earlyReturn(ok bool) optional.Optional[*user] {
+	var u *user
+	if ok {
+		u = &user{}
+	}
+	if u == nil {
+		return optional.Empty[*user]()
+	}
+	return optional.Of(u)
+}
+
+func interfaceValue(ok bool) optional.Optional[error] {
+	var err error
+	if ok {
+		err = errors.New("failed")
+	}
+	return optional.Of(err) // want `Of called with an error that may be nil; use OfErr instead`
+}
+
+func open() error { return nil }
+
+func errorResult() optional.Optional[error] {
+	return optional.Of(open()) // want `Of called with an error that may be nil; use OfErr instead`
+}
+
+func checkedError() optional.Optional[error] {
+	if err := open(); err != nil {
+		return optional.Of(err)
+	}
+	return optional.Empty[error]()
+}
+
+func errorHelper() optional.Optional[error] {
+	return optional.OfErr(open())
+}
+
+func boxedPointer(ok bool) optional.Optional[any] {
+	var u *user
+	if ok {
+		u = &user{}
+	}
+	return optional.Of[any](u) // want `argument to Of may be nil; use OfNilable instead`
+}
+
+func nilInterface(ok bool) optional.Optional[fmt.Stringer] {
+	var s fmt.Stringer
+	if ok {
+		s = stringer{}
+	}
+	return optional.Of(s) // want `argument to Of may be nil; use OfNilable instead`
+}
+
+type stringer struct{}
+
+func (stringer) String() string { return "" }
+
+func allocated() optional.Optional[*user] {
+	return optional.Of(&user{name: "a"})
+}
+
+func unknown(id int) optional.Optional[*user] {
+	return optional.Of(lookup(id))
+}
+
+func value() optional.Optional[int] {
+	return optional.Of(42)
+}
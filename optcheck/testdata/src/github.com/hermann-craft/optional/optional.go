@@ -0,0 +1,25 @@
+package optional
+
+type Optional[T any] struct {
+	value *T
+}
+
+func Empty[T any]() Optional[T] { return Optional[T]{} }
+
+func Of[T any](value T) Optional[T] { return Optional[T]{value: &value} }
+
+func OfErr(err error) Optional[error] { return Optional[error]{} }
+
+func OfNullable[T any](value *T) Optional[T] { return Optional[T]{value: value} }
+
+func OfNilable[T any](value T) Optional[T] { return Optional[T]{value: &value} }
+
+func (o Optional[T]) IsPresent() bool { return o.value != nil }
+
+func (o Optional[T]) IsEmpty() bool { return o.value == nil }
+
+func (o Optional[T]) Get() T { return *o.value }
+
+func (o Optional[T]) OrElse(other T) T { return other }
+
+func (o Optional[T]) OrElseThrow(err error) T { return *o.value }
@@ -0,0 +1,263 @@
+// Package optdump renders values containing Optionals in a stable, readable
+// form suitable for golden files and test failure messages.
+//
+// Optional fields are printed as their value when present and as <empty>
+// otherwise, instead of the pointer addresses produced by %+v:
+//
+//	User{
+//	  Name: "Ada"
+//	  Email: <empty>
+//	}
+//
+// Options customises the indentation, the marker of empty Optionals and
+// ANSI coloring, and Dump uses them for REPL-style debugging and verbose
+// command-line output.
+package optdump
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hermann-craft/optional/reflectopt"
+)
+
+// Options configures the rendering.
+type Options struct {
+	// Indent is repeated once per nesting level. It defaults to two spaces.
+	Indent string
+	// Empty is printed for empty Optionals. It defaults to <empty>.
+	Empty string
+	// Color highlights names, strings, numbers and empty values with ANSI
+	// escape sequences.
+	Color bool
+}
+
+// ANSI escape sequences used when Options.Color is set.
+const (
+	colorReset  = "\x1b[0m"
+	colorName   = "\x1b[36m"
+	colorString = "\x1b[32m"
+	colorNumber = "\x1b[33m"
+	colorEmpty  = "\x1b[2m"
+	colorType   = "\x1b[1m"
+)
+
+// Sprint returns the rendering of v.
+func Sprint(v any) string {
+	return Options{}.Sprint(v)
+}
+
+// Fprint writes the rendering of v to w, followed by a newline.
+func Fprint(w io.Writer, v any) error {
+	return Options{}.Fprint(w, v)
+}
+
+// Dump writes the rendering of v to w, followed by a newline, printing
+// empty Optionals as ∅ and coloring the output when w is a terminal and
+// the NO_COLOR environment variable is not set.
+func Dump(w io.Writer, v any) error {
+	return Options{Empty: "∅", Color: isTerminal(w)}.Fprint(w, v)
+}
+
+// Sprint returns the rendering of v with the options o.
+func (o Options) Sprint(v any) string {
+	if o.Indent == "" {
+		o.Indent = "  "
+	}
+	if o.Empty == "" {
+		o.Empty = "<empty>"
+	}
+	p := &printer{opts: o, seen: map[uintptr]bool{}}
+	p.value(reflect.ValueOf(v), 0)
+	return p.String()
+}
+
+// Fprint writes the rendering of v with the options o to w, followed by a
+// newline.
+func (o Options) Fprint(w io.Writer, v any) error {
+	_, err := io.WriteString(w, o.Sprint(v)+"\n")
+	return err
+}
+
+// isTerminal reports whether colored output suits w.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+type printer struct {
+	strings.Builder
+	opts Options
+	seen map[uintptr]bool
+}
+
+func (p *printer) indent(depth int) {
+	p.WriteString(strings.Repeat(p.opts.Indent, depth))
+}
+
+// colored writes s, wrapped in the escape sequence color when coloring.
+func (p *printer) colored(color, s string) {
+	if !p.opts.Color {
+		p.WriteString(s)
+		return
+	}
+	p.WriteString(color)
+	p.WriteString(s)
+	p.WriteString(colorReset)
+}
+
+func (p *printer) value(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		p.colored(colorEmpty, "nil")
+		return
+	}
+	// Types controlling their own log or Go-syntax output, such as
+	// optional.Sensitive, are rendered through it before anything looks
+	// inside them, so that redacted values stay redacted.
+	if v.CanInterface() && v.Kind() != reflect.Interface && !(v.Kind() == reflect.Pointer && v.IsNil()) {
+		switch x := v.Interface().(type) {
+		case slog.LogValuer:
+			if lv := x.LogValue().Resolve(); lv.Any() != nil {
+				p.value(reflect.ValueOf(lv.Any()), depth)
+			} else {
+				p.colored(colorEmpty, p.opts.Empty)
+			}
+			return
+		case fmt.GoStringer:
+			p.WriteString(x.GoString())
+			return
+		}
+	}
+	if v.CanInterface() && reflectopt.IsOptionalType(v.Type()) {
+		inner, ok := reflectopt.ValueOf(v)
+		if !ok {
+			p.colored(colorEmpty, p.opts.Empty)
+			return
+		}
+		p.value(inner, depth)
+		return
+	}
+	if v.CanInterface() && v.Kind() != reflect.Interface && !(v.Kind() == reflect.Pointer && v.IsNil()) {
+		switch x := v.Interface().(type) {
+		case error:
+			p.WriteString(x.Error())
+			return
+		case fmt.Stringer:
+			p.WriteString(x.String())
+			return
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		p.colored(colorString, strconv.Quote(v.String()))
+	case reflect.Pointer:
+		if v.IsNil() {
+			p.colored(colorEmpty, "nil")
+			return
+		}
+		if p.seen[v.Pointer()] {
+			p.WriteString("<cycle>")
+			return
+		}
+		p.seen[v.Pointer()] = true
+		defer delete(p.seen, v.Pointer())
+		p.WriteString("&")
+		p.value(v.Elem(), depth)
+	case reflect.Interface:
+		if v.IsNil() {
+			p.colored(colorEmpty, "nil")
+			return
+		}
+		p.value(v.Elem(), depth)
+	case reflect.Struct:
+		p.structValue(v, depth)
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			p.WriteString("[]")
+			return
+		}
+		p.WriteString("[\n")
+		for i := 0; i < v.Len(); i++ {
+			p.indent(depth + 1)
+			p.value(v.Index(i), depth+1)
+			p.WriteString("\n")
+		}
+		p.indent(depth)
+		p.WriteString("]")
+	case reflect.Map:
+		if v.Len() == 0 {
+			p.WriteString("{}")
+			return
+		}
+		keys := v.MapKeys()
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = Sprint(k.Interface())
+		}
+		sort.Sort(byName{keys, names})
+		p.WriteString("{\n")
+		for i, k := range keys {
+			p.indent(depth + 1)
+			p.colored(colorName, names[i])
+			p.WriteString(": ")
+			p.value(v.MapIndex(k), depth+1)
+			p.WriteString("\n")
+		}
+		p.indent(depth)
+		p.WriteString("}")
+	default:
+		if v.CanInterface() {
+			p.colored(colorNumber, fmt.Sprint(v.Interface()))
+		} else {
+			p.colored(colorNumber, fmt.Sprint(v))
+		}
+	}
+}
+
+func (p *printer) structValue(v reflect.Value, depth int) {
+	t := v.Type()
+	p.colored(colorType, t.Name())
+	var fields []int
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			fields = append(fields, i)
+		}
+	}
+	if len(fields) == 0 {
+		p.WriteString("{}")
+		return
+	}
+	p.WriteString("{\n")
+	for _, i := range fields {
+		p.indent(depth + 1)
+		p.colored(colorName, t.Field(i).Name)
+		p.WriteString(": ")
+		p.value(v.Field(i), depth+1)
+		p.WriteString("\n")
+	}
+	p.indent(depth)
+	p.WriteString("}")
+}
+
+type byName struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (b byName) Len() int           { return len(b.keys) }
+func (b byName) Less(i, j int) bool { return b.names[i] < b.names[j] }
+func (b byName) Swap(i, j int) {
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+	b.names[i], b.names[j] = b.names[j], b.names[i]
+}
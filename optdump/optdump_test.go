@@ -0,0 +1,147 @@
+package optdump
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+type address struct {
+	City optional.Optional[string]
+	Zip  string
+}
+
+type user struct {
+	Name    string
+	Email   optional.Optional[string]
+	Age     optional.Optional[int]
+	Address optional.Optional[address]
+	Tags    []string
+	Meta    map[string]optional.Optional[int]
+	Manager *user
+	secret  string
+}
+
+func TestSprintStruct(t *testing.T) {
+	u := user{
+		Name:    "Ada",
+		Age:     optional.Of(36),
+		Address: optional.Of(address{City: optional.Of("London")}),
+		Tags:    []string{"admin", "ops"},
+		Meta:    map[string]optional.Optional[int]{"b": optional.Empty[int](), "a": optional.Of(1)},
+		secret:  "hidden",
+	}
+	want := `user{
+  Name: "Ada"
+  Email: <empty>
+  Age: 36
+  Address: address{
+    City: "London"
+    Zip: ""
+  }
+  Tags: [
+    "admin"
+    "ops"
+  ]
+  Meta: {
+    "a": 1
+    "b": <empty>
+  }
+  Manager: nil
+}`
+	if got := Sprint(u); got != want {
+		t.Errorf("Expected:\n%s\nbut got:\n%s", want, got)
+	}
+}
+
+func TestSprintScalars(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{nil, "nil"},
+		{optional.Empty[int](), "<empty>"},
+		{optional.Of("x"), `"x"`},
+		{optional.Of(&address{Zip: "1"}), "&address{\n  City: <empty>\n  Zip: \"1\"\n}"},
+		{[]int{}, "[]"},
+		{time.Duration(90) * time.Second, "1m30s"},
+		{errors.New("boom"), "boom"},
+	}
+	for _, tt := range tests {
+		if got := Sprint(tt.value); got != tt.want {
+			t.Errorf("Expected %q, but got %q", tt.want, got)
+		}
+	}
+}
+
+func TestSprintCycle(t *testing.T) {
+	u := &user{Name: "loop"}
+	u.Manager = u
+	if got := Sprint(u); !strings.Contains(got, "Manager: <cycle>") {
+		t.Errorf("Expected cycle marker, but got:\n%s", got)
+	}
+}
+
+func TestFprint(t *testing.T) {
+	var b strings.Builder
+	if err := Fprint(&b, optional.Of(1)); err != nil || b.String() != "1\n" {
+		t.Errorf("Expected \"1\\n\", but got %q (err %v)", b.String(), err)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	u := user{Name: "Ada", Age: optional.Of(36), Meta: map[string]optional.Optional[int]{"x": optional.Empty[int]()}}
+	got := Options{Indent: "\t", Empty: "∅"}.Sprint(u)
+	for _, want := range []string{"\tName: \"Ada\"\n", "\tEmail: ∅\n", "\tAge: 36\n", "\t\t\"x\": ∅\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected the output to contain %q, but got:\n%s", want, got)
+		}
+	}
+
+	colored := Options{Color: true}.Sprint(u)
+	for _, want := range []string{
+		colorType + "user" + colorReset,
+		colorName + "Name" + colorReset + ": " + colorString + `"Ada"` + colorReset,
+		colorEmpty + "<empty>" + colorReset,
+		colorNumber + "36" + colorReset,
+	} {
+		if !strings.Contains(colored, want) {
+			t.Errorf("Expected the output to contain %q, but got %q", want, colored)
+		}
+	}
+}
+
+func TestDump(t *testing.T) {
+	var b strings.Builder
+	if err := Dump(&b, optional.Empty[int]()); err != nil || b.String() != "∅\n" {
+		t.Errorf("Expected \"∅\\n\" without color, but got %q (err %v)", b.String(), err)
+	}
+}
+
+func TestDumpSensitive(t *testing.T) {
+	type credentials struct {
+		User     string
+		Password optional.Sensitive[string]
+		Token    optional.Sensitive[string]
+	}
+	var b strings.Builder
+	if err := Dump(&b, credentials{User: "ada", Password: optional.Secret("hunter2")}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	got := b.String()
+	if strings.Contains(got, "hunter2") {
+		t.Errorf("Expected the password to be redacted, but got %s", got)
+	}
+	want := `credentials{
+  User: "ada"
+  Password: "[REDACTED]"
+  Token: ∅
+}
+`
+	if got != want {
+		t.Errorf("Expected %s, but got %s", want, got)
+	}
+}
@@ -1,25 +1,30 @@
 package optional
 
-import (
-	"fmt"
-	"reflect"
-)
+import "reflect"
 
 // Optional represents a container that may or may not hold a value.
 type Optional[T any] struct {
-	value *T
+	origin origin
+	value  *T
 }
 
 // Empty creates an empty Optional instance.
 func Empty[T any]() Optional[T] {
-	return Optional[T]{value: nil}
+	return Optional[T]{origin: captureOrigin(), value: nil}
 }
 
 // Of creates an Optional containing a non-nil value.
-// It panics if the value is nil to ensure explicit non-null usage.
+// It panics if the value is nil to ensure explicit non-null usage, unless
+// another NilPolicy was chosen with SetNilPolicy.
 func Of[T any](value T) Optional[T] {
 	// Vérifie explicitement si le type est un pointeur et si la valeur est nil
 	if isNil(value) {
+		switch NilPolicy(nilPolicy.Load()) {
+		case EmptyOnNil:
+			return Optional[T]{origin: captureOrigin()}
+		case ErrorOnNil:
+			panic(ErrNilValue)
+		}
 		panic("Optional.Of: value cannot be nil")
 	}
 	return Optional[T]{value: &value}
@@ -35,9 +40,45 @@ func isNil[T any](value T) bool {
 // OfNullable creates an Optional containing the value if it is non-nil, otherwise an empty Optional.
 // Supports cases where the input value is nil.
 func OfNullable[T any](value *T) Optional[T] {
+	if value == nil {
+		return Optional[T]{origin: captureOrigin()}
+	}
 	return Optional[T]{value: value}
 }
 
+// OfNilable creates an Optional containing the value unless it is nil,
+// otherwise an empty Optional. Unlike OfNullable, it recognises every kind
+// of nil: nil pointers, maps, slices, channels and functions, and
+// interfaces that are nil or hold such a nil value.
+func OfNilable[T any](value T) Optional[T] {
+	if isNilValue(reflect.ValueOf(&value).Elem()) {
+		return Optional[T]{origin: captureOrigin()}
+	}
+	return Optional[T]{value: &value}
+}
+
+// OfAnyNullable creates an Optional containing value unless it is nil or
+// an interface holding a nil pointer, map, slice, channel or function,
+// which compares unequal to nil and is the classic source of nil pointer
+// dereferences behind a non-nil error or any.
+func OfAnyNullable(value any) Optional[any] {
+	if isNilValue(reflect.ValueOf(&value).Elem()) {
+		return Optional[any]{origin: captureOrigin()}
+	}
+	return Optional[any]{value: &value}
+}
+
+// isNilValue reports whether v is nil, looking through interfaces.
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Interface:
+		return v.IsNil() || isNilValue(v.Elem())
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return false
+}
+
 // IsPresent returns true if the Optional contains a value.
 func (o Optional[T]) IsPresent() bool {
 	return o.value != nil
@@ -48,10 +89,10 @@ func (o Optional[T]) IsEmpty() bool {
 	return o.value == nil
 }
 
-// Get returns the value if present, otherwise it panics.
+// Get returns the value if present, otherwise it panics with an *AbsentError.
 func (o Optional[T]) Get() T {
 	if o.IsEmpty() {
-		panic("Optional.Get: no value present")
+		panic(&AbsentError{origin: o.origin.describe()})
 	}
 	return *o.value
 }
@@ -73,6 +114,25 @@ func (o Optional[T]) IfPresentOrElse(action func(T), emptyAction func()) {
 	}
 }
 
+// IfPresentErr performs the given action with the value if it is present
+// and returns its error. It returns nil if the Optional is empty.
+func (o Optional[T]) IfPresentErr(action func(T) error) error {
+	if o.IsPresent() {
+		return action(*o.value)
+	}
+	return nil
+}
+
+// IfPresentOrElseErr performs the given action with the value if it is
+// present, otherwise performs the given empty action, and returns the error
+// of whichever ran.
+func (o Optional[T]) IfPresentOrElseErr(action func(T) error, emptyAction func() error) error {
+	if o.IsPresent() {
+		return action(*o.value)
+	}
+	return emptyAction()
+}
+
 // OrElse returns the value if present, otherwise returns the provided default value.
 func (o Optional[T]) OrElse(other T) T {
 	if o.IsPresent() {
@@ -89,20 +149,49 @@ func (o Optional[T]) OrElseGet(supplier func() T) T {
 	return supplier()
 }
 
-// OrElseThrow returns the value if present, otherwise it panics with the provided error.
+// OrZero returns the value if present, otherwise the zero value of T.
+func (o Optional[T]) OrZero() T {
+	if o.IsPresent() {
+		return *o.value
+	}
+	var zero T
+	return zero
+}
+
+// OrElseThrow returns the value if present, otherwise it panics with an
+// *AbsentError wrapping the provided error.
 func (o Optional[T]) OrElseThrow(err error) T {
 	if o.IsPresent() {
 		return *o.value
 	}
-	panic(err)
+	panic(&AbsentError{Err: err})
 }
 
 // Map applies the given function to the value if present and returns an Optional describing the result.
+// A nil pointer result gives an empty Optional, so pipelines degrade instead of panicking.
 func Map[T, U any](opt Optional[T], mapper func(T) U) Optional[U] {
 	if opt.IsEmpty() {
 		return Empty[U]()
 	}
-	return Of(mapper(opt.Get()))
+	return ofResult(mapper(opt.Get()))
+}
+
+// MapNilable is like Map, but gives an empty Optional for every kind of nil
+// result, as OfNilable does, including nil maps, slices and interfaces.
+func MapNilable[T, U any](opt Optional[T], mapper func(T) U) Optional[U] {
+	if opt.IsEmpty() {
+		return Empty[U]()
+	}
+	return OfNilable(mapper(opt.Get()))
+}
+
+// ofResult creates an Optional holding the result of a mapping function,
+// or an empty Optional if it is a nil pointer.
+func ofResult[T any](value T) Optional[T] {
+	if isNil(value) {
+		return Optional[T]{origin: captureOrigin()}
+	}
+	return Optional[T]{value: &value}
 }
 
 // FlatMap applies the given function to the value if present and returns the result directly.
@@ -113,10 +202,29 @@ func FlatMap[T, U any](opt Optional[T], mapper func(T) Optional[U]) Optional[U]
 	return mapper(opt.Get())
 }
 
-// String returns a string representation of the Optional.
+// MapOr applies the given function to the value if present and returns the
+// result, otherwise returns the provided default value.
+func MapOr[T, U any](opt Optional[T], def U, mapper func(T) U) U {
+	if opt.IsEmpty() {
+		return def
+	}
+	return mapper(opt.Get())
+}
+
+// MapOrElse applies the given function to the value if present and returns
+// the result, otherwise computes a default using the given supplier.
+func MapOrElse[T, U any](opt Optional[T], supplier func() U, mapper func(T) U) U {
+	if opt.IsEmpty() {
+		return supplier()
+	}
+	return mapper(opt.Get())
+}
+
+// String returns a string representation of the Optional, formatting the
+// value with the formatter registered for T by RegisterFormatter, if any.
 func (o Optional[T]) String() string {
 	if o.IsPresent() {
-		return fmt.Sprintf("Optional[%v]", *o.value)
+		return "Optional[" + Format(*o.value) + "]"
 	}
 	return "Optional.empty"
 }
@@ -3,6 +3,7 @@ package optional
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"testing"
 )
 
@@ -52,6 +53,33 @@ func TestOptionalOfNullable(t *testing.T) {
 	}
 }
 
+func TestOptionalOfNilable(t *testing.T) {
+	var p *int
+	var m map[string]int
+	var err error = (*fs.PathError)(nil)
+	if OfNilable(p).IsPresent() || OfNilable(m).IsPresent() || OfNilable[[]int](nil).IsPresent() || OfNilable(err).IsPresent() {
+		t.Errorf("Expected nil values to give empty optionals, but one was present")
+	}
+	if opt := OfNilable([]int{}); !opt.IsPresent() {
+		t.Errorf("Expected an empty non-nil slice to be present, but it was not")
+	}
+	if opt := OfNilable(0); !opt.IsPresent() {
+		t.Errorf("Expected zero to be present, but it was not")
+	}
+}
+
+func TestOptionalOfAnyNullable(t *testing.T) {
+	var p *int
+	for _, v := range []any{nil, p, map[string]int(nil), []int(nil), (func())(nil)} {
+		if opt := OfAnyNullable(v); opt.IsPresent() {
+			t.Errorf("Expected %#v to give an empty optional, but it was present", v)
+		}
+	}
+	if opt := OfAnyNullable("a"); !opt.IsPresent() || opt.Get() != "a" {
+		t.Errorf("Expected a, but got %v", opt)
+	}
+}
+
 func TestOptionalGet(t *testing.T) {
 	opt := Of(42)
 	if opt.Get() != 42 {
@@ -123,6 +151,35 @@ func TestOptionalIfPresentOrElse(t *testing.T) {
 	}
 }
 
+func TestOptionalIfPresentErr(t *testing.T) {
+	errWrite := errors.New("write failed")
+	if err := Of(42).IfPresentErr(func(int) error { return errWrite }); err != errWrite {
+		t.Errorf("Expected error %v, but got %v", errWrite, err)
+	}
+	if err := Of(42).IfPresentErr(func(int) error { return nil }); err != nil {
+		t.Errorf("Expected no error, but got %v", err)
+	}
+	if err := Empty[int]().IfPresentErr(func(int) error {
+		t.Errorf("Expected action not to be called")
+		return errWrite
+	}); err != nil {
+		t.Errorf("Expected no error for empty optional, but got %v", err)
+	}
+}
+
+func TestOptionalIfPresentOrElseErr(t *testing.T) {
+	errPresent := errors.New("present")
+	errEmpty := errors.New("empty")
+	action := func(int) error { return errPresent }
+	emptyAction := func() error { return errEmpty }
+	if err := Of(42).IfPresentOrElseErr(action, emptyAction); err != errPresent {
+		t.Errorf("Expected error %v, but got %v", errPresent, err)
+	}
+	if err := Empty[int]().IfPresentOrElseErr(action, emptyAction); err != errEmpty {
+		t.Errorf("Expected error %v, but got %v", errEmpty, err)
+	}
+}
+
 func TestOptionalOrElse(t *testing.T) {
 	opt := Empty[int]()
 	val := opt.OrElse(100)
@@ -155,6 +212,18 @@ func TestOptionalOrElseGet(t *testing.T) {
 	}
 }
 
+func TestOptionalOrZero(t *testing.T) {
+	if val := Empty[int]().OrZero(); val != 0 {
+		t.Errorf("Expected zero value 0, but got %d", val)
+	}
+	if val := Empty[string]().OrZero(); val != "" {
+		t.Errorf("Expected zero value \"\", but got %q", val)
+	}
+	if val := Of(42).OrZero(); val != 42 {
+		t.Errorf("Expected value 42, but got %d", val)
+	}
+}
+
 func TestOptionalOrElseThrow(t *testing.T) {
 	opt := Of(42)
 	val := opt.OrElseThrow(errors.New("error"))
@@ -189,6 +258,26 @@ func TestOptionalMap(t *testing.T) {
 	}
 }
 
+func TestOptionalMapNilResult(t *testing.T) {
+	var missing *int
+	mapped := Map(Of(42), func(int) *int { return missing })
+	if mapped.IsPresent() {
+		t.Errorf("Expected a nil pointer result to give an empty optional, but it was present")
+	}
+	if got := Map2(Of(1), Of(2), func(int, int) *int { return nil }); got.IsPresent() {
+		t.Errorf("Expected Map2 to give an empty optional for a nil pointer, but it was present")
+	}
+	if got := Map(Of(42), func(int) []int { return nil }); !got.IsPresent() {
+		t.Errorf("Expected Map to keep a nil slice, but it was empty")
+	}
+	if got := MapNilable(Of(42), func(int) []int { return nil }); got.IsPresent() {
+		t.Errorf("Expected MapNilable to give an empty optional for a nil slice, but it was present")
+	}
+	if got := MapNilable(Of(42), func(v int) []int { return []int{v} }); got.OrZero()[0] != 42 {
+		t.Errorf("Expected [42], but got %v", got)
+	}
+}
+
 func TestOptionalFlatMap(t *testing.T) {
 	opt := Of(42)
 	flatMapped := FlatMap(opt, func(val int) Optional[string] {
@@ -207,6 +296,31 @@ func TestOptionalFlatMap(t *testing.T) {
 	}
 }
 
+func TestOptionalMapOr(t *testing.T) {
+	length := func(s string) int { return len(s) }
+	if val := MapOr(Of("hello"), -1, length); val != 5 {
+		t.Errorf("Expected mapped value 5, but got %d", val)
+	}
+	if val := MapOr(Empty[string](), -1, length); val != -1 {
+		t.Errorf("Expected default value -1, but got %d", val)
+	}
+}
+
+func TestOptionalMapOrElse(t *testing.T) {
+	length := func(s string) int { return len(s) }
+	called := false
+	fallback := func() int {
+		called = true
+		return -1
+	}
+	if val := MapOrElse(Of("hello"), fallback, length); val != 5 || called {
+		t.Errorf("Expected mapped value 5 without calling the supplier, but got %d", val)
+	}
+	if val := MapOrElse(Empty[string](), fallback, length); val != -1 || !called {
+		t.Errorf("Expected computed value -1, but got %d", val)
+	}
+}
+
 func TestOptionalString(t *testing.T) {
 	opt := Of(42)
 	if opt.String() != "Optional[42]" {
@@ -0,0 +1,110 @@
+package opttest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hermann-craft/optional/reflectopt"
+)
+
+// Matcher has the method set of gomega's types.GomegaMatcher, so the
+// matchers below work with Expect and Ω without this package importing
+// gomega:
+//
+//	Expect(user.Nickname).To(opttest.BePresent())
+//	Expect(user.Age).To(opttest.HaveOptionalValue(42))
+//	Expect(user.Email).To(opttest.HaveOptionalValue(HaveSuffix("@example.com")))
+type Matcher interface {
+	Match(actual any) (success bool, err error)
+	FailureMessage(actual any) (message string)
+	NegatedFailureMessage(actual any) (message string)
+}
+
+// BePresent succeeds if actual is an Optional holding a value.
+func BePresent() Matcher {
+	return presenceMatcher{present: true}
+}
+
+// BeEmptyOptional succeeds if actual is an empty Optional.
+func BeEmptyOptional() Matcher {
+	return presenceMatcher{present: false}
+}
+
+// HaveOptionalValue succeeds if actual is an Optional holding a value equal
+// to expected, compared with reflect.DeepEqual, or matching expected if it
+// is itself a matcher.
+func HaveOptionalValue(expected any) Matcher {
+	return valueMatcher{expected: expected}
+}
+
+type presenceMatcher struct {
+	present bool
+}
+
+func (m presenceMatcher) Match(actual any) (bool, error) {
+	_, ok, err := optionalValue(actual)
+	return ok == m.present, err
+}
+
+func (m presenceMatcher) FailureMessage(actual any) string {
+	if m.present {
+		return message(actual, "to be present")
+	}
+	return message(actual, "to be an empty Optional")
+}
+
+func (m presenceMatcher) NegatedFailureMessage(actual any) string {
+	return presenceMatcher{!m.present}.FailureMessage(actual)
+}
+
+type valueMatcher struct {
+	expected any
+}
+
+func (m valueMatcher) Match(actual any) (bool, error) {
+	v, ok, err := optionalValue(actual)
+	if err != nil || !ok {
+		return false, err
+	}
+	if inner, isMatcher := m.expected.(Matcher); isMatcher {
+		return inner.Match(v.Interface())
+	}
+	return reflect.DeepEqual(v.Interface(), m.expected), nil
+}
+
+func (m valueMatcher) FailureMessage(actual any) string {
+	if inner, isMatcher := m.expected.(Matcher); isMatcher {
+		if v, ok, _ := optionalValue(actual); ok {
+			return inner.FailureMessage(v.Interface())
+		}
+	}
+	return message(actual, fmt.Sprintf("to hold value\n    <%T>: %v", m.expected, m.expected))
+}
+
+func (m valueMatcher) NegatedFailureMessage(actual any) string {
+	if inner, isMatcher := m.expected.(Matcher); isMatcher {
+		if v, ok, _ := optionalValue(actual); ok {
+			return inner.NegatedFailureMessage(v.Interface())
+		}
+	}
+	return message(actual, fmt.Sprintf("not to hold value\n    <%T>: %v", m.expected, m.expected))
+}
+
+// optionalValue returns the value held by the Optional actual, or by the
+// Optional it points to.
+func optionalValue(actual any) (reflect.Value, bool, error) {
+	v := reflect.ValueOf(actual)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || !reflectopt.IsOptionalType(v.Type()) {
+		return reflect.Value{}, false, fmt.Errorf("expected an Optional, got\n    <%T>: %v", actual, actual)
+	}
+	value, ok := reflectopt.ValueOf(v)
+	return value, ok, nil
+}
+
+// message formats a failure message in the style of gomega.
+func message(actual any, expectation string) string {
+	return fmt.Sprintf("Expected\n    <%T>: %v\n%s", actual, actual, expectation)
+}
@@ -0,0 +1,60 @@
+package opttest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+// prefixMatcher mirrors a gomega matcher such as HavePrefix.
+type prefixMatcher string
+
+func (m prefixMatcher) Match(actual any) (bool, error) {
+	return strings.HasPrefix(actual.(string), string(m)), nil
+}
+
+func (m prefixMatcher) FailureMessage(any) string        { return "prefix failure" }
+func (m prefixMatcher) NegatedFailureMessage(any) string { return "negated prefix failure" }
+
+func TestBePresent(t *testing.T) {
+	if ok, err := BePresent().Match(optional.Of(1)); !ok || err != nil {
+		t.Errorf("Expected a present Optional to match, but got %v, %v", ok, err)
+	}
+	if ok, _ := BePresent().Match(optional.Empty[int]()); ok {
+		t.Errorf("Expected an empty Optional not to match, but it did")
+	}
+	if ok, err := BeEmptyOptional().Match(new(optional.Optional[string])); !ok || err != nil {
+		t.Errorf("Expected a pointer to an empty Optional to match, but got %v, %v", ok, err)
+	}
+	if _, err := BePresent().Match(42); err == nil {
+		t.Errorf("Expected an error for a non-Optional, but got nil")
+	}
+	if msg := BePresent().FailureMessage(optional.Empty[int]()); !strings.Contains(msg, "Optional.empty") || !strings.HasSuffix(msg, "to be present") {
+		t.Errorf("Expected a gomega-style failure message, but got %q", msg)
+	}
+	if msg := BePresent().NegatedFailureMessage(optional.Of(1)); !strings.HasSuffix(msg, "to be an empty Optional") {
+		t.Errorf("Expected the negated message, but got %q", msg)
+	}
+}
+
+func TestHaveOptionalValue(t *testing.T) {
+	if ok, err := HaveOptionalValue([]int{1, 2}).Match(optional.Of([]int{1, 2})); !ok || err != nil {
+		t.Errorf("Expected equal values to match, but got %v, %v", ok, err)
+	}
+	if ok, _ := HaveOptionalValue(42).Match(optional.Of(41)); ok {
+		t.Errorf("Expected different values not to match, but they did")
+	}
+	if ok, _ := HaveOptionalValue(42).Match(optional.Empty[int]()); ok {
+		t.Errorf("Expected an empty Optional not to match, but it did")
+	}
+	if ok, _ := HaveOptionalValue(prefixMatcher("ada")).Match(optional.Of("ada@example.com")); !ok {
+		t.Errorf("Expected the nested matcher to match, but it did not")
+	}
+	if msg := HaveOptionalValue(prefixMatcher("bob")).FailureMessage(optional.Of("ada")); msg != "prefix failure" {
+		t.Errorf("Expected the nested matcher's message, but got %q", msg)
+	}
+	if msg := HaveOptionalValue(42).FailureMessage(optional.Of(41)); !strings.Contains(msg, "to hold value\n    <int>: 42") {
+		t.Errorf("Expected the expected value in the message, but got %q", msg)
+	}
+}
@@ -0,0 +1,198 @@
+// Package opttest provides helpers for testing code built on the optional
+// package.
+package opttest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+// Laws checks the functor and monad laws of a Map and FlatMap
+// implementation for a set of mappers, together with the presence
+// invariants of every Optional involved.
+type Laws[T, U, V any] struct {
+	// Samples are the values the laws are checked with. An empty Optional is
+	// always checked in addition to them.
+	Samples []T
+
+	// F and G are composed to check the functor laws.
+	F func(T) U
+	G func(U) V
+
+	// KF and KG are composed to check the monad laws.
+	KF func(T) optional.Optional[U]
+	KG func(U) optional.Optional[V]
+
+	// Of wraps a sample into an Optional. It defaults to optional.Of.
+	Of func(T) optional.Optional[T]
+
+	// Map and FlatMap are the implementations under test, instantiated for
+	// each pair of types the laws use. Nil functions default to optional.Map
+	// and optional.FlatMap.
+	Map     Maps[T, U, V]
+	FlatMap FlatMaps[T, U, V]
+}
+
+// MapFunc is the signature of optional.Map for given types.
+type MapFunc[A, B any] func(optional.Optional[A], func(A) B) optional.Optional[B]
+
+// FlatMapFunc is the signature of optional.FlatMap for given types.
+type FlatMapFunc[A, B any] func(optional.Optional[A], func(A) optional.Optional[B]) optional.Optional[B]
+
+// Maps holds a Map implementation instantiated for the type pairs of Laws.
+type Maps[T, U, V any] struct {
+	TT MapFunc[T, T]
+	TU MapFunc[T, U]
+	UV MapFunc[U, V]
+	TV MapFunc[T, V]
+}
+
+// FlatMaps holds a FlatMap implementation instantiated for the type pairs
+// of Laws.
+type FlatMaps[T, U, V any] struct {
+	TT FlatMapFunc[T, T]
+	TU FlatMapFunc[T, U]
+	UV FlatMapFunc[U, V]
+	TV FlatMapFunc[T, V]
+}
+
+// Check reports every violated law through t. Laws whose mappers are nil are
+// skipped.
+func (l Laws[T, U, V]) Check(t testing.TB) {
+	t.Helper()
+	of := l.Of
+	if of == nil {
+		of = optional.Of[T]
+	}
+	maps, flatMaps := l.Map.withDefaults(), l.FlatMap.withDefaults()
+
+	inputs := []optional.Optional[T]{optional.Empty[T]()}
+	for _, s := range l.Samples {
+		m := of(s)
+		if m.IsEmpty() {
+			t.Errorf("Of(%v) returned an empty Optional", s)
+		}
+		inputs = append(inputs, m)
+	}
+
+	for _, m := range inputs {
+		Invariants(t, m)
+
+		if got := maps.TT(m, func(v T) T { return v }); !Equal(got, m) {
+			t.Errorf("functor identity: Map(%v, id) = %v", m, got)
+		}
+		if l.F != nil && l.G != nil {
+			lhs := maps.TV(m, func(v T) V { return l.G(l.F(v)) })
+			rhs := maps.UV(maps.TU(m, l.F), l.G)
+			Invariants(t, lhs)
+			if !Equal(lhs, rhs) {
+				t.Errorf("functor composition: Map(%v, G∘F) = %v, but Map(Map(%[1]v, F), G) = %v", m, lhs, rhs)
+			}
+		}
+
+		if got := flatMaps.TT(m, of); !Equal(got, m) {
+			t.Errorf("monad right identity: FlatMap(%v, Of) = %v", m, got)
+		}
+		if l.KF != nil && l.KG != nil {
+			lhs := flatMaps.UV(flatMaps.TU(m, l.KF), l.KG)
+			rhs := flatMaps.TV(m, func(v T) optional.Optional[V] { return flatMaps.UV(l.KF(v), l.KG) })
+			Invariants(t, lhs)
+			if !Equal(lhs, rhs) {
+				t.Errorf("monad associativity: FlatMap(FlatMap(%v, KF), KG) = %v, but FlatMap(%[1]v, KF>=>KG) = %v", m, lhs, rhs)
+			}
+		}
+	}
+
+	if l.KF != nil {
+		for _, s := range l.Samples {
+			lhs, rhs := flatMaps.TU(of(s), l.KF), l.KF(s)
+			if !Equal(lhs, rhs) {
+				t.Errorf("monad left identity: FlatMap(Of(%v), KF) = %v, but KF(%[1]v) = %v", s, lhs, rhs)
+			}
+		}
+	}
+}
+
+// withDefaults returns m with its nil functions set to optional.Map.
+func (m Maps[T, U, V]) withDefaults() Maps[T, U, V] {
+	if m.TT == nil {
+		m.TT = optional.Map[T, T]
+	}
+	if m.TU == nil {
+		m.TU = optional.Map[T, U]
+	}
+	if m.UV == nil {
+		m.UV = optional.Map[U, V]
+	}
+	if m.TV == nil {
+		m.TV = optional.Map[T, V]
+	}
+	return m
+}
+
+// withDefaults returns m with its nil functions set to optional.FlatMap.
+func (m FlatMaps[T, U, V]) withDefaults() FlatMaps[T, U, V] {
+	if m.TT == nil {
+		m.TT = optional.FlatMap[T, T]
+	}
+	if m.TU == nil {
+		m.TU = optional.FlatMap[T, U]
+	}
+	if m.UV == nil {
+		m.UV = optional.FlatMap[U, V]
+	}
+	if m.TV == nil {
+		m.TV = optional.FlatMap[T, V]
+	}
+	return m
+}
+
+// Invariants checks that the accessors of o agree on whether it holds a
+// value, reporting every disagreement through t.
+func Invariants[T any](t testing.TB, o optional.Optional[T]) {
+	t.Helper()
+	present := o.IsPresent()
+	if present == o.IsEmpty() {
+		t.Errorf("%v: IsPresent() and IsEmpty() both returned %v", o, present)
+	}
+	value, panicked := get(o)
+	if panicked == present {
+		t.Errorf("%v: Get() panicked = %v, but IsPresent() = %v", o, panicked, present)
+	}
+	var fallback T
+	if got := o.OrElse(fallback); present && !reflect.DeepEqual(got, value) {
+		t.Errorf("%v: OrElse returned %v, but Get returned %v", o, got, value)
+	}
+	called := false
+	o.IfPresent(func(T) { called = true })
+	if called != present {
+		t.Errorf("%v: IfPresent called the action = %v, but IsPresent() = %v", o, called, present)
+	}
+	want := "Optional.empty"
+	if present {
+		want = "Optional[" + optional.Format(value) + "]"
+	}
+	if got := o.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+// Equal reports whether a and b are both empty, or both present with deeply
+// equal values.
+func Equal[T any](a, b optional.Optional[T]) bool {
+	if a.IsPresent() != b.IsPresent() {
+		return false
+	}
+	return a.IsEmpty() || reflect.DeepEqual(a.Get(), b.Get())
+}
+
+func get[T any](o optional.Optional[T]) (value T, panicked bool) {
+	defer func() {
+		if recover() != nil {
+			panicked = true
+		}
+	}()
+	return o.Get(), false
+}
@@ -0,0 +1,122 @@
+package opttest
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+// recorder captures failures reported through testing.TB.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestLawsHold(t *testing.T) {
+	Laws[int, string, int]{
+		Samples: []int{0, 1, 42},
+		F:       strconv.Itoa,
+		G:       func(s string) int { return len(s) },
+		KF: func(v int) optional.Optional[string] {
+			if v == 0 {
+				return optional.Empty[string]()
+			}
+			return optional.Of(strconv.Itoa(v))
+		},
+		KG: func(s string) optional.Optional[int] {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return optional.Empty[int]()
+			}
+			return optional.Of(n * 2)
+		},
+	}.Check(t)
+}
+
+func TestLawsDetectBrokenOf(t *testing.T) {
+	r := &recorder{}
+	Laws[int, int, int]{
+		Samples: []int{1},
+		Of:      func(int) optional.Optional[int] { return optional.Empty[int]() },
+	}.Check(r)
+	if len(r.failures) == 0 {
+		t.Errorf("Expected failures for a constructor returning empty, but got none")
+	}
+}
+
+func TestLawsDetectImpureMapper(t *testing.T) {
+	calls := 0
+	r := &recorder{}
+	Laws[int, int, int]{
+		Samples: []int{1},
+		F:       func(v int) int { calls++; return v + calls },
+		G:       func(v int) int { return v },
+	}.Check(r)
+	if len(r.failures) == 0 {
+		t.Errorf("Expected failures for an impure mapper, but got none")
+	}
+}
+
+// lazyMap is a Map implementation built on FlatMap, as a downstream
+// wrapper might define it.
+func lazyMap[A, B any](o optional.Optional[A], f func(A) B) optional.Optional[B] {
+	return optional.FlatMap(o, func(v A) optional.Optional[B] { return optional.Of(f(v)) })
+}
+
+func TestLawsCustomMap(t *testing.T) {
+	Laws[int, string, int]{
+		Samples: []int{0, 7},
+		F:       strconv.Itoa,
+		G:       func(s string) int { return len(s) },
+		Map:     Maps[int, string, int]{TT: lazyMap[int, int], TU: lazyMap[int, string], UV: lazyMap[string, int], TV: lazyMap[int, int]},
+	}.Check(t)
+
+	r := &recorder{}
+	Laws[int, int, int]{
+		Samples: []int{1},
+		F:       func(v int) int { return v + 1 },
+		G:       func(v int) int { return v * 2 },
+		Map: Maps[int, int, int]{TV: func(optional.Optional[int], func(int) int) optional.Optional[int] {
+			return optional.Empty[int]()
+		}},
+	}.Check(r)
+	if len(r.failures) == 0 {
+		t.Errorf("Expected failures for a Map dropping values, but got none")
+	}
+}
+
+type maskedToken string
+
+func TestInvariantsFormatter(t *testing.T) {
+	optional.RegisterFormatter(func(maskedToken) string { return "***" })
+	r := &recorder{}
+	Invariants(r, optional.Of(maskedToken("secret")))
+	if len(r.failures) != 0 {
+		t.Errorf("Expected String to match the registered formatter, but got %v", r.failures)
+	}
+}
+
+func TestInvariants(t *testing.T) {
+	Invariants(t, optional.Of("value"))
+	Invariants(t, optional.Empty[string]())
+}
+
+func TestEqual(t *testing.T) {
+	if !Equal(optional.Of([]int{1}), optional.Of([]int{1})) {
+		t.Errorf("Expected optionals with equal slices to be equal")
+	}
+	if Equal(optional.Of(1), optional.Empty[int]()) {
+		t.Errorf("Expected present and empty optionals to differ")
+	}
+	if !Equal(optional.Empty[int](), optional.Empty[int]()) {
+		t.Errorf("Expected empty optionals to be equal")
+	}
+}
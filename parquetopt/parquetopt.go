@@ -0,0 +1,194 @@
+// Package parquetopt writes and reads structs with Optional fields with
+// github.com/parquet-go/parquet-go, mapping each Optional field to an
+// OPTIONAL parquet field, so empty Optionals are stored as nulls through
+// definition levels and nulls read back as empty Optionals.
+//
+// parquet-go derives schemas from struct types by reflection and represents
+// optional fields as pointers. For a struct type T, RowOf builds a row value
+// of a mirror type in which every Optional[E] field is a *E tagged
+// optional, and FromRow converts such a row back:
+//
+//	w := parquet.NewWriter(f, parquet.SchemaOf(parquetopt.Prototype[Event]()))
+//	for _, e := range events {
+//		if err := parquetopt.Write(w, e); err != nil {
+//			return err
+//		}
+//	}
+//
+//	r := parquet.NewReader(f)
+//	e, err := parquetopt.Read[Event](r)
+//
+// Fields keep their parquet tags, and nested structs are mirrored as well.
+// The package relies only on the Write and Read methods of *parquet.Writer
+// and *parquet.Reader, so it does not import parquet-go itself.
+package parquetopt
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/hermann-craft/optional/reflectopt"
+)
+
+// Writer is implemented by *parquet.Writer.
+type Writer interface {
+	Write(row any) error
+}
+
+// Reader is implemented by *parquet.Reader.
+type Reader interface {
+	Read(row any) error
+}
+
+// Prototype returns a pointer to a zero row of the mirror type of T, for
+// parquet.SchemaOf.
+func Prototype[T any]() any {
+	return reflect.New(rowType(reflect.TypeFor[T]())).Interface()
+}
+
+// RowOf returns a pointer to the row mirroring v.
+func RowOf[T any](v T) any {
+	row := reflect.New(rowType(reflect.TypeFor[T]()))
+	toRow(row.Elem(), reflect.ValueOf(v))
+	return row.Interface()
+}
+
+// FromRow converts a row built by RowOf or Prototype back into a T.
+func FromRow[T any](row any) (T, error) {
+	var v T
+	rv := reflect.ValueOf(row)
+	if rv.Kind() != reflect.Pointer || rv.Type().Elem() != rowType(reflect.TypeFor[T]()) {
+		return v, fmt.Errorf("parquetopt: %T is not a row of %T", row, v)
+	}
+	err := fromRow(reflect.ValueOf(&v).Elem(), rv.Elem())
+	return v, err
+}
+
+// Write writes v to w as a row of its mirror type.
+func Write[T any](w Writer, v T) error {
+	return w.Write(RowOf(v))
+}
+
+// Read reads the next row of r into a T.
+func Read[T any](r Reader) (T, error) {
+	row := Prototype[T]()
+	if err := r.Read(row); err != nil {
+		var zero T
+		return zero, err
+	}
+	return FromRow[T](row)
+}
+
+// rowTypes caches the mirror type of each struct type.
+var rowTypes sync.Map
+
+// rowType returns the mirror type of t: Optional[E] becomes *E tagged
+// optional and structs containing Optionals are mirrored recursively,
+// keeping only their exported fields. Other types are returned unchanged.
+func rowType(t reflect.Type) reflect.Type {
+	if cached, ok := rowTypes.Load(t); ok {
+		return cached.(reflect.Type)
+	}
+	if elem, ok := optionalElem(t); ok {
+		return reflect.PointerTo(rowType(elem))
+	}
+	if t.Kind() != reflect.Struct {
+		return t
+	}
+	var fields []reflect.StructField
+	changed := false
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		ft := rowType(f.Type)
+		if _, ok := optionalElem(f.Type); ok {
+			f.Tag = optionalTag(f.Tag)
+		}
+		changed = changed || ft != f.Type
+		fields = append(fields, reflect.StructField{Name: f.Name, Type: ft, Tag: f.Tag})
+	}
+	row := t
+	if changed {
+		row = reflect.StructOf(fields)
+	}
+	rowTypes.Store(t, row)
+	return row
+}
+
+// optionalTag adds the optional option to the parquet tag of tag.
+func optionalTag(tag reflect.StructTag) reflect.StructTag {
+	parquet, ok := tag.Lookup("parquet")
+	if !ok {
+		return reflect.StructTag(strings.TrimSpace(string(tag) + ` parquet:",optional"`))
+	}
+	for _, o := range strings.Split(parquet, ",")[1:] {
+		if o == "optional" {
+			return tag
+		}
+	}
+	return reflect.StructTag(strings.Replace(string(tag), `parquet:"`+parquet+`"`, `parquet:"`+parquet+`,optional"`, 1))
+}
+
+func toRow(dst, src reflect.Value) {
+	if dst.Type() == src.Type() {
+		dst.Set(src)
+		return
+	}
+	if _, ok := optionalElem(src.Type()); ok {
+		inner, ok := reflectopt.ValueOf(src)
+		if !ok {
+			return
+		}
+		p := reflect.New(dst.Type().Elem())
+		toRow(p.Elem(), inner)
+		dst.Set(p)
+		return
+	}
+	t := src.Type()
+	for i, j := 0, 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			toRow(dst.Field(j), src.Field(i))
+			j++
+		}
+	}
+}
+
+func fromRow(dst, src reflect.Value) error {
+	if dst.Type() == src.Type() {
+		dst.Set(src)
+		return nil
+	}
+	if elem, ok := optionalElem(dst.Type()); ok {
+		var value any
+		if !src.IsNil() {
+			v := reflect.New(elem).Elem()
+			if err := fromRow(v, src.Elem()); err != nil {
+				return err
+			}
+			value = v.Interface()
+		}
+		return dst.Addr().Interface().(sql.Scanner).Scan(value)
+	}
+	t := dst.Type()
+	for i, j := 0, 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		if err := fromRow(dst.Field(i), src.Field(j)); err != nil {
+			return fmt.Errorf("parquetopt: field %s: %w", t.Field(i).Name, err)
+		}
+		j++
+	}
+	return nil
+}
+
+// optionalElem returns the type of the value held by the Optional type t.
+func optionalElem(t reflect.Type) (reflect.Type, bool) {
+	elem := reflectopt.ElemType(t)
+	return elem, elem != nil
+}
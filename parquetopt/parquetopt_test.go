@@ -0,0 +1,112 @@
+package parquetopt
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+type location struct {
+	City    string
+	Country optional.Optional[string]
+}
+
+type event struct {
+	ID       string                    `parquet:"id"`
+	At       time.Time                 `parquet:"at,timestamp"`
+	User     optional.Optional[string] `parquet:"user_id"`
+	Amount   optional.Optional[float64]
+	Location optional.Optional[location] `parquet:"location"`
+	Origin   location
+	internal int
+}
+
+func TestRowType(t *testing.T) {
+	rt := rowType(reflect.TypeFor[event]())
+	tests := []struct {
+		field string
+		typ   reflect.Type
+		tag   reflect.StructTag
+	}{
+		{"ID", reflect.TypeFor[string](), `parquet:"id"`},
+		{"At", reflect.TypeFor[time.Time](), `parquet:"at,timestamp"`},
+		{"User", reflect.TypeFor[*string](), `parquet:"user_id,optional"`},
+		{"Amount", reflect.TypeFor[*float64](), `parquet:",optional"`},
+		{"Location", reflect.PointerTo(rowType(reflect.TypeFor[location]())), `parquet:"location,optional"`},
+	}
+	for _, tt := range tests {
+		f, ok := rt.FieldByName(tt.field)
+		if !ok || f.Type != tt.typ || f.Tag != tt.tag {
+			t.Errorf("Expected field %s of type %s tagged %s, but got %s tagged %s", tt.field, tt.typ, tt.tag, f.Type, f.Tag)
+		}
+	}
+	if _, ok := rt.FieldByName("internal"); ok {
+		t.Errorf("Expected unexported fields to be dropped")
+	}
+	if rowType(reflect.TypeFor[event]()) != rt {
+		t.Errorf("Expected the row type to be cached")
+	}
+}
+
+// rows mimics *parquet.Writer and *parquet.Reader by keeping written rows.
+type rows struct {
+	written []any
+}
+
+func (r *rows) Write(row any) error {
+	r.written = append(r.written, row)
+	return nil
+}
+
+func (r *rows) Read(row any) error {
+	if len(r.written) == 0 {
+		return io.EOF
+	}
+	reflect.ValueOf(row).Elem().Set(reflect.ValueOf(r.written[0]).Elem())
+	r.written = r.written[1:]
+	return nil
+}
+
+func TestRoundTrip(t *testing.T) {
+	at := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	in := []event{
+		{ID: "e1", At: at, User: optional.Of("u1"), Location: optional.Of(location{City: "Paris", Country: optional.Of("FR")}), Origin: location{City: "Lyon"}},
+		{ID: "e2", At: at, Amount: optional.Of(9.5)},
+	}
+	var r rows
+	for _, e := range in {
+		if err := Write(&r, e); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+	}
+	if user := reflect.ValueOf(r.written[1]).Elem().FieldByName("User"); !user.IsNil() {
+		t.Errorf("Expected an empty Optional to be written as nil, but got %v", user)
+	}
+	for _, want := range in {
+		got, err := Read[event](&r)
+		if err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if got.ID != want.ID || !got.At.Equal(want.At) || !optional.Equal(got.User, want.User) ||
+			!optional.Equal(got.Amount, want.Amount) || got.Origin.City != want.Origin.City {
+			t.Errorf("Expected %+v, but got %+v", want, got)
+		}
+		if got.Location.IsPresent() != want.Location.IsPresent() ||
+			(got.Location.IsPresent() && !optional.Equal(got.Location.Get().Country, want.Location.Get().Country)) {
+			t.Errorf("Expected location %v, but got %v", want.Location, got.Location)
+		}
+	}
+	if _, err := Read[event](&r); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected io.EOF, but got %v", err)
+	}
+}
+
+func TestFromRowWrongType(t *testing.T) {
+	if _, err := FromRow[event](&location{}); err == nil {
+		t.Errorf("Expected an error for a row of another type, but got nil")
+	}
+}
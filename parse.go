@@ -0,0 +1,76 @@
+package optional
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration parses s like time.ParseDuration, returning an empty
+// Optional if s is blank or malformed, so a default can be applied:
+//
+//	timeout := optional.ParseDuration(os.Getenv("TIMEOUT")).OrElse(30 * time.Second)
+func ParseDuration(s string) Optional[time.Duration] {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return Empty[time.Duration]()
+	}
+	return Of(d)
+}
+
+// byteUnits maps the lower-case byte size suffixes to their multipliers.
+var byteUnits = map[string]float64{
+	"": 1, "b": 1,
+	"k": 1e3, "kb": 1e3, "kib": 1 << 10,
+	"m": 1e6, "mb": 1e6, "mib": 1 << 20,
+	"g": 1e9, "gb": 1e9, "gib": 1 << 30,
+	"t": 1e12, "tb": 1e12, "tib": 1 << 40,
+	"p": 1e15, "pb": 1e15, "pib": 1 << 50,
+}
+
+// ParseBytes parses a byte size such as "512MiB", "1.5GB" or "4096" into a
+// number of bytes, returning an empty Optional if s is blank, malformed,
+// negative or too large. Units are case-insensitive; KB, MB and so on are
+// powers of 1000 and KiB, MiB and so on powers of 1024.
+func ParseBytes(s string) Optional[int64] {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(s)
+	}
+	n, err := strconv.ParseFloat(s[:i], 64)
+	unit, ok := byteUnits[strings.ToLower(strings.TrimSpace(s[i:]))]
+	if err != nil || !ok {
+		return Empty[int64]()
+	}
+	bytes := math.Round(n * unit)
+	if bytes >= math.MaxInt64 {
+		return Empty[int64]()
+	}
+	return Of(int64(bytes))
+}
+
+// ParseOptional parses the representation String produces, "Optional.empty"
+// or "Optional[...]", parsing the text between the brackets with
+// parseInner, so Optionals can round-trip through logs, test fixtures and
+// simple text protocols:
+//
+//	o, err := optional.ParseOptional("Optional[42]", strconv.Atoi)
+func ParseOptional[T any](s string, parseInner func(string) (T, error)) (Optional[T], error) {
+	s = strings.TrimSpace(s)
+	if s == "Optional.empty" {
+		return Empty[T](), nil
+	}
+	inner, prefixed := strings.CutPrefix(s, "Optional[")
+	inner, suffixed := strings.CutSuffix(inner, "]")
+	if !prefixed || !suffixed {
+		return Empty[T](), fmt.Errorf("optional: cannot parse %q: want Optional.empty or Optional[...]", s)
+	}
+	v, err := parseInner(inner)
+	if err != nil {
+		return Empty[T](), fmt.Errorf("optional: cannot parse %q: %w", s, err)
+	}
+	return Optional[T]{value: &v}, nil
+}
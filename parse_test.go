@@ -0,0 +1,69 @@
+package optional
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	if got := ParseDuration(" 1h30m "); got.OrElse(0) != 90*time.Minute {
+		t.Errorf("Expected Optional[1h30m0s], but got %v", got)
+	}
+	for _, s := range []string{"", "soon", "10"} {
+		if got := ParseDuration(s); got.IsPresent() {
+			t.Errorf("Expected an empty Optional for %q, but got %v", s, got)
+		}
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := map[string]int64{
+		"4096":    4096,
+		"512MiB":  512 << 20,
+		"1.5GB":   1_500_000_000,
+		"10 kb":   10_000,
+		"2KiB":    2048,
+		"0.5k":    500,
+		"1 TiB":   1 << 40,
+		"100B":    100,
+		"8 EiB":   0,
+		"":        0,
+		"lots":    0,
+		"-1MB":    0,
+		"1.2.3MB": 0,
+		"99999PB": 0,
+	}
+	for in, want := range tests {
+		got := ParseBytes(in)
+		if want == 0 {
+			if got.IsPresent() {
+				t.Errorf("Expected an empty Optional for %q, but got %v", in, got)
+			}
+			continue
+		}
+		if got.OrElse(0) != want {
+			t.Errorf("Expected %d for %q, but got %v", want, in, got)
+		}
+	}
+}
+
+func TestParseOptional(t *testing.T) {
+	for _, o := range []Optional[int]{Of(42), Empty[int]()} {
+		got, err := ParseOptional(o.String(), strconv.Atoi)
+		if err != nil || !Equal(got, o) {
+			t.Errorf("Expected %v to round-trip, but got %v, %v", o, got, err)
+		}
+	}
+	if got, err := ParseOptional("Optional[[a]]", func(s string) (string, error) { return s, nil }); err != nil || got.OrZero() != "[a]" {
+		t.Errorf("Expected Optional[[a]], but got %v, %v", got, err)
+	}
+	if got, err := ParseOptional("Optional[<nil>]", func(string) (*int, error) { return nil, nil }); err != nil || !got.IsPresent() || got.Get() != nil {
+		t.Errorf("Expected a present nil, but got %v, %v", got, err)
+	}
+	for _, s := range []string{"", "42", "Optional[42", "42]", "Optional[x]"} {
+		if _, err := ParseOptional(s, strconv.Atoi); err == nil {
+			t.Errorf("Expected an error for %q, but got nil", s)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ApplyPatch copies every present Optional field of patch into the field of
+// target with the same name, or failing that the same json tag name. Target
+// fields may be plain values, pointers or Optionals. Nullable patch fields
+// set to null reset the target field to its zero value. Struct fields of
+// patch, including those held in an Optional, are applied recursively to the
+// matching target field.
+func ApplyPatch[T, P any](target *T, patch P) error {
+	_, err := ApplyPatchChanges(target, patch)
+	return err
+}
+
+// ApplyPatchChanges is like ApplyPatch but also returns the dotted paths of
+// the patch fields whose application changed the target.
+func ApplyPatchChanges[T, P any](target *T, patch P) ([]string, error) {
+	if target == nil {
+		return nil, errors.New("optional: ApplyPatch called with nil target")
+	}
+	p := &patcher{}
+	err := p.apply(reflect.ValueOf(target).Elem(), reflect.ValueOf(patch), "")
+	return p.changed, err
+}
+
+// patcher applies patch structs and records the fields it changed.
+type patcher struct {
+	changed []string
+	// lenient skips patch fields that have no match in the target or are
+	// neither Optionals, Nullables nor structs, instead of failing.
+	lenient bool
+}
+
+func (p *patcher) apply(dst, src reflect.Value, path string) error {
+	src = indirect(src)
+	if !src.IsValid() {
+		return nil
+	}
+	if src.Kind() != reflect.Struct || dst.Kind() != reflect.Struct {
+		return fmt.Errorf("optional: cannot patch %s with %s", dst.Type(), src.Type())
+	}
+	t := src.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := path + f.Name
+		df, ok := matchingField(dst, f)
+		if !ok {
+			if p.lenient {
+				continue
+			}
+			return fmt.Errorf("optional: %s has no field matching patch field %s", dst.Type(), name)
+		}
+		sv := src.Field(i)
+		switch {
+		case isNullableType(sv.Type()):
+			set, v, present := sv.Interface().(reflectedNullable).nullableValue()
+			switch {
+			case !set:
+			case !present:
+				p.set(df, name, func() { df.Set(reflect.Zero(df.Type())) })
+			default:
+				if err := p.assign(df, v, name); err != nil {
+					return err
+				}
+			}
+		case isOptionalType(sv.Type()):
+			v, present := optionalValue(sv)
+			if !present {
+				continue
+			}
+			if sv.Type() == df.Type() {
+				p.set(df, name, func() { df.Set(sv) })
+				continue
+			}
+			if err := p.assign(df, v, name); err != nil {
+				return err
+			}
+		case indirect(sv).Kind() == reflect.Struct || (sv.Kind() == reflect.Pointer && sv.Type().Elem().Kind() == reflect.Struct):
+			if err := p.patchStruct(df, sv, name); err != nil {
+				return err
+			}
+		case p.lenient:
+		default:
+			return fmt.Errorf("optional: patch field %s must be an Optional, a Nullable or a struct, not %s", name, sv.Type())
+		}
+	}
+	return nil
+}
+
+// set runs update on the field dst and records path if its value changed.
+func (p *patcher) set(dst reflect.Value, path string, update func()) {
+	before := dst.Interface()
+	update()
+	if !reflect.DeepEqual(before, dst.Interface()) {
+		p.changed = append(p.changed, path)
+	}
+}
+
+// assign stores v into dst, which may be a plain value, a pointer or an
+// Optional. Structs that cannot be assigned directly are patched recursively.
+func (p *patcher) assign(dst, v reflect.Value, path string) error {
+	t := dst.Type()
+	switch {
+	case isOptionalType(t):
+		if cv, ok := convertTo(v, optionalElem(t)); ok {
+			p.set(dst, path, func() { setOptional(dst, cv) })
+			return nil
+		}
+	case t.Kind() == reflect.Pointer:
+		if _, ok := convertTo(v, t); ok {
+			break
+		}
+		if cv, ok := convertTo(v, t.Elem()); ok {
+			ptr := reflect.New(t.Elem())
+			ptr.Elem().Set(cv)
+			p.set(dst, path, func() { dst.Set(ptr) })
+			return nil
+		}
+	}
+	if cv, ok := convertTo(v, t); ok {
+		p.set(dst, path, func() { dst.Set(cv) })
+		return nil
+	}
+	if indirect(v).Kind() == reflect.Struct {
+		return p.patchStruct(dst, v, path)
+	}
+	return fmt.Errorf("optional: cannot assign %s to field %s of type %s", v.Type(), path, t)
+}
+
+// patchStruct applies the patch struct src to dst, which may be a struct, a
+// pointer to a struct or an Optional holding one.
+func (p *patcher) patchStruct(dst, src reflect.Value, path string) error {
+	if !indirect(src).IsValid() {
+		return nil
+	}
+	t := dst.Type()
+	switch {
+	case isOptionalType(t):
+		current, present := optionalValue(dst)
+		if !present {
+			current = reflect.New(optionalElem(t)).Elem()
+		}
+		if err := p.apply(current, src, path+"."); err != nil {
+			return err
+		}
+		setOptional(dst, current)
+		return nil
+	case t.Kind() == reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(t.Elem()))
+		}
+		return p.apply(dst.Elem(), src, path+".")
+	}
+	return p.apply(dst, src, path+".")
+}
+
+// Diff returns a patch of type P holding, for each of its fields, the value
+// of the matching field of after when it differs from before. Fields are
+// matched as in ApplyPatch, so applying the patch to before yields after.
+// Fields that became empty are recorded as null in Nullable patch fields;
+// Optional patch fields cannot express them and are left empty.
+func Diff[P, T any](before, after T) (P, error) {
+	var patch P
+	_, err := diff(reflect.ValueOf(&patch).Elem(), reflect.ValueOf(before), reflect.ValueOf(after), "")
+	return patch, err
+}
+
+// diff fills the patch struct dst from the structs before and after and
+// reports whether any field of dst was set.
+func diff(dst, before, after reflect.Value, path string) (bool, error) {
+	before, after = indirect(before), indirect(after)
+	switch {
+	case !before.IsValid() && !after.IsValid():
+		return false, nil
+	case !before.IsValid():
+		before = reflect.Zero(after.Type())
+	case !after.IsValid():
+		after = reflect.Zero(before.Type())
+	}
+	if dst.Kind() != reflect.Struct || before.Kind() != reflect.Struct {
+		return false, fmt.Errorf("optional: cannot diff %s into %s", before.Type(), dst.Type())
+	}
+	changed := false
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := path + f.Name
+		bf, ok := matchingField(before, f)
+		if !ok {
+			return false, fmt.Errorf("optional: %s has no field matching patch field %s", before.Type(), name)
+		}
+		af, _ := matchingField(after, f)
+		pf := dst.Field(i)
+		switch {
+		case isNullableType(pf.Type()):
+			bv, bp := fieldValue(bf)
+			av, ap := fieldValue(af)
+			if bp == ap && (!ap || reflect.DeepEqual(bv.Interface(), av.Interface())) {
+				continue
+			}
+			setter := pf.Addr().Interface().(nullableSetter)
+			if !ap {
+				setter.setNullable(reflect.Value{})
+				changed = true
+				continue
+			}
+			cv, ok := convertTo(av, pf.Interface().(reflectedNullable).nullableElem())
+			if !ok {
+				return false, fmt.Errorf("optional: cannot store %s in patch field %s of type %s", av.Type(), name, pf.Type())
+			}
+			setter.setNullable(cv)
+			changed = true
+		case isOptionalType(pf.Type()):
+			bv, bp := fieldValue(bf)
+			av, ap := fieldValue(af)
+			if !ap || (bp && reflect.DeepEqual(bv.Interface(), av.Interface())) {
+				continue
+			}
+			elem := optionalElem(pf.Type())
+			if cv, ok := convertTo(av, elem); ok {
+				setOptional(pf, cv)
+				changed = true
+				continue
+			}
+			if elem.Kind() != reflect.Struct || av.Kind() != reflect.Struct {
+				return false, fmt.Errorf("optional: cannot store %s in patch field %s of type %s", av.Type(), name, pf.Type())
+			}
+			nested := reflect.New(elem).Elem()
+			c, err := diff(nested, bv, av, name+".")
+			if err != nil {
+				return false, err
+			}
+			if c {
+				setOptional(pf, nested)
+				changed = true
+			}
+		case pf.Kind() == reflect.Struct:
+			c, err := diff(pf, bf, af, name+".")
+			if err != nil {
+				return false, err
+			}
+			changed = changed || c
+		default:
+			return false, fmt.Errorf("optional: patch field %s must be an Optional, a Nullable or a struct, not %s", name, pf.Type())
+		}
+	}
+	return changed, nil
+}
+
+// fieldValue returns the value held by the field v, which may be a plain
+// value, a pointer or an Optional, and whether there is one.
+func fieldValue(v reflect.Value) (reflect.Value, bool) {
+	switch {
+	case isOptionalType(v.Type()):
+		return optionalValue(v)
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		return v.Elem(), true
+	}
+	return v, true
+}
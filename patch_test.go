@@ -0,0 +1,150 @@
+package optional
+
+import (
+	"strings"
+	"testing"
+)
+
+type patchAddress struct {
+	City    string
+	Country string
+}
+
+type patchUser struct {
+	Name     string
+	Email    *string
+	Age      Optional[int]
+	Address  patchAddress
+	Previous *patchAddress
+	Nickname string `json:"nick"`
+}
+
+type patchAddressPatch struct {
+	City Optional[string]
+}
+
+type patchUserPatch struct {
+	Name     Optional[string]
+	Email    Optional[string]
+	Age      Optional[int]
+	Address  patchAddressPatch
+	Previous Optional[patchAddressPatch]
+	Nick     Optional[string] `json:"nick"`
+}
+
+func TestApplyPatch(t *testing.T) {
+	user := patchUser{Name: "Ada", Age: Of(36), Address: patchAddress{City: "London", Country: "UK"}}
+	patch := patchUserPatch{
+		Email:    Of("ada@example.com"),
+		Age:      Of(37),
+		Address:  patchAddressPatch{City: Of("Paris")},
+		Previous: Of(patchAddressPatch{City: Of("London")}),
+		Nick:     Of("countess"),
+	}
+	if err := ApplyPatch(&user, patch); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if user.Name != "Ada" {
+		t.Errorf("Expected absent field to be left alone, but got %q", user.Name)
+	}
+	if user.Email == nil || *user.Email != "ada@example.com" {
+		t.Errorf("Expected pointer field to be set, but got %v", user.Email)
+	}
+	if user.Age.OrElse(0) != 37 {
+		t.Errorf("Expected Optional field 37, but got %v", user.Age)
+	}
+	if user.Address.City != "Paris" || user.Address.Country != "UK" {
+		t.Errorf("Expected nested struct to be patched, but got %+v", user.Address)
+	}
+	if user.Previous == nil || user.Previous.City != "London" {
+		t.Errorf("Expected nil nested pointer to be allocated and patched, but got %+v", user.Previous)
+	}
+	if user.Nickname != "countess" {
+		t.Errorf("Expected field matched by json tag to be set, but got %q", user.Nickname)
+	}
+}
+
+func TestApplyPatchPointerPatch(t *testing.T) {
+	user := patchUser{Name: "Ada"}
+	if err := ApplyPatch(&user, &patchUserPatch{Name: Of("Grace")}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if user.Name != "Grace" {
+		t.Errorf("Expected name Grace, but got %q", user.Name)
+	}
+	if err := ApplyPatch(&user, (*patchUserPatch)(nil)); err != nil {
+		t.Errorf("Expected nil patch to be a no-op, but got %v", err)
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	user := patchUser{}
+	tests := []struct {
+		name  string
+		patch any
+		want  string
+	}{
+		{"unknown field", struct{ Unknown Optional[int] }{Of(1)}, "no field matching"},
+		{"type mismatch", struct{ Name Optional[int] }{Of(1)}, "cannot assign"},
+		{"plain field", struct{ Name string }{"x"}, "must be an Optional"},
+	}
+	for _, tt := range tests {
+		err := ApplyPatch(&user, tt.patch)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, but got %v", tt.name, tt.want, err)
+		}
+	}
+	if err := ApplyPatch[patchUser](nil, patchUserPatch{}); err == nil {
+		t.Errorf("Expected error for nil target, but got none")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	email := "ada@example.com"
+	before := patchUser{Name: "Ada", Age: Of(36), Address: patchAddress{City: "London"}, Nickname: "ada"}
+	after := patchUser{Name: "Ada", Email: &email, Age: Of(37), Address: patchAddress{City: "Paris"}, Previous: &patchAddress{City: "London"}, Nickname: "ada"}
+
+	patch, err := Diff[patchUserPatch](before, after)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if patch.Name.IsPresent() || patch.Nick.IsPresent() {
+		t.Errorf("Expected unchanged fields to be empty, but got %v and %v", patch.Name, patch.Nick)
+	}
+	if patch.Email.OrElse("") != email || patch.Age.OrElse(0) != 37 {
+		t.Errorf("Expected changed fields in patch, but got %v and %v", patch.Email, patch.Age)
+	}
+	if patch.Address.City.OrElse("") != "Paris" {
+		t.Errorf("Expected nested change in patch, but got %v", patch.Address.City)
+	}
+	if !patch.Previous.IsPresent() || patch.Previous.Get().City.OrElse("") != "London" {
+		t.Errorf("Expected nested Optional patch, but got %v", patch.Previous)
+	}
+
+	if err := ApplyPatch(&before, patch); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if before.Age.OrElse(0) != 37 || *before.Email != email || before.Address.City != "Paris" || before.Previous.City != "London" {
+		t.Errorf("Expected patch to turn before into after, but got %+v", before)
+	}
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	user := patchUser{Name: "Ada", Address: patchAddress{City: "London"}}
+	patch, err := Diff[patchUserPatch](user, user)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if patch.Name.IsPresent() || patch.Address.City.IsPresent() || patch.Previous.IsPresent() {
+		t.Errorf("Expected empty patch, but got %+v", patch)
+	}
+}
+
+func TestDiffErrors(t *testing.T) {
+	if _, err := Diff[struct{ Unknown Optional[int] }](patchUser{}, patchUser{}); err == nil {
+		t.Errorf("Expected error for unmatched field, but got none")
+	}
+	if _, err := Diff[struct{ Name Optional[int] }](patchUser{}, patchUser{Name: "x"}); err == nil {
+		t.Errorf("Expected error for mismatched type, but got none")
+	}
+}
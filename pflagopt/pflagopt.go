@@ -0,0 +1,85 @@
+// Package pflagopt binds command-line flags to Optional variables with
+// github.com/spf13/pflag, and so with cobra, whose commands expose their
+// flags as pflag FlagSets. A flag that is not given leaves its Optional
+// empty, so it can be told apart from one set to the default value:
+//
+//	var port optional.Optional[int]
+//	pflagopt.OptionalVar(cmd.Flags(), &port, "port", "port to listen on")
+//
+// Flags are added through the AddGoFlag method of *pflag.FlagSet, so the
+// package does not import pflag itself. A flag with a shorthand letter is
+// defined with pflag directly, passing a Value, and a boolean one also needs
+// its NoOptDefVal:
+//
+//	fs.VarPF(pflagopt.NewValue(&verbose), "verbose", "v", "log more").NoOptDefVal = "true"
+package pflagopt
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hermann-craft/optional"
+)
+
+// Value is a pflag.Value, and a flag.Value, setting an Optional. Values are
+// parsed with the Optional's UnmarshalText.
+type Value[T any] struct {
+	target *optional.Optional[T]
+}
+
+// NewValue returns a Value setting the Optional p points to.
+func NewValue[T any](p *optional.Optional[T]) *Value[T] {
+	return &Value[T]{target: p}
+}
+
+// String returns the current value as text, or "" if empty.
+func (v *Value[T]) String() string {
+	if v == nil || v.target == nil || v.target.IsEmpty() {
+		return ""
+	}
+	if text, err := v.target.MarshalText(); err == nil {
+		return string(text)
+	}
+	return fmt.Sprint(v.target.Get())
+}
+
+// Set parses s into the Optional.
+func (v *Value[T]) Set(s string) error {
+	return v.target.UnmarshalText([]byte(s))
+}
+
+// Type returns the name of T for help output.
+func (v *Value[T]) Type() string {
+	if name := reflect.TypeFor[T]().Name(); name != "" {
+		return strings.ToLower(name)
+	}
+	return "value"
+}
+
+// IsBoolFlag reports whether the flag may be given without a value, for the
+// standard flag package.
+func (v *Value[T]) IsBoolFlag() bool {
+	return reflect.TypeFor[T]().Kind() == reflect.Bool
+}
+
+// FlagSet is the method of *pflag.FlagSet the package uses.
+type FlagSet interface {
+	AddGoFlag(f *flag.Flag)
+}
+
+// OptionalVar defines a flag with the given name and usage setting the
+// Optional p points to. Boolean flags may be given without a value. Like
+// AddGoFlag, it does nothing if fs already has a flag with that name.
+func OptionalVar[T any](fs FlagSet, p *optional.Optional[T], name, usage string) {
+	fs.AddGoFlag(&flag.Flag{Name: name, Usage: usage, Value: NewValue(p)})
+}
+
+// OptionalFlag defines a flag with the given name and usage and returns the
+// Optional it sets.
+func OptionalFlag[T any](fs FlagSet, name, usage string) *optional.Optional[T] {
+	p := new(optional.Optional[T])
+	OptionalVar(fs, p, name, usage)
+	return p
+}
@@ -0,0 +1,71 @@
+package pflagopt
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+// flagSet mirrors *pflag.FlagSet, whose AddGoFlag defines the given flag
+// unless one with that name exists.
+type flagSet struct {
+	*flag.FlagSet
+}
+
+func newFlagSet() flagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(new(strings.Builder))
+	return flagSet{fs}
+}
+
+func (fs flagSet) AddGoFlag(f *flag.Flag) {
+	if fs.Lookup(f.Name) == nil {
+		fs.Var(f.Value, f.Name, f.Usage)
+	}
+}
+
+func TestOptionalVar(t *testing.T) {
+	fs := newFlagSet()
+	var port optional.Optional[int]
+	var name optional.Optional[string]
+	OptionalVar(fs, &port, "port", "port")
+	OptionalVar(fs, &name, "name", "name")
+	verbose := OptionalFlag[bool](fs, "verbose", "verbose")
+	timeout := OptionalFlag[time.Duration](fs, "timeout", "timeout")
+
+	if err := fs.Parse([]string{"-port", "8080", "-verbose", "--timeout=5s"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if port.OrElse(0) != 8080 || !verbose.OrElse(false) || timeout.OrElse(0) != 5*time.Second {
+		t.Errorf("Expected 8080, true and 5s, but got %v, %v and %v", port, *verbose, *timeout)
+	}
+	if name.IsPresent() {
+		t.Errorf("Expected an unset flag to stay empty, but got %v", name)
+	}
+	if got := fs.Lookup("timeout").Value.String(); got != "5s" {
+		t.Errorf("Expected 5s, but got %q", got)
+	}
+	if got := fs.Lookup("timeout").Value.(*Value[time.Duration]).Type(); got != "duration" {
+		t.Errorf("Expected duration, but got %q", got)
+	}
+}
+
+func TestInvalidValue(t *testing.T) {
+	fs := newFlagSet()
+	OptionalFlag[int](fs, "port", "port")
+	if err := fs.Parse([]string{"--port=eighty"}); err == nil {
+		t.Error("Expected an error, but got nil")
+	}
+}
+
+func TestStdlibFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var debug optional.Optional[bool]
+	fs.Var(NewValue(&debug), "debug", "debug")
+	if err := fs.Parse([]string{"-debug"}); err != nil || !debug.OrElse(false) {
+		t.Errorf("Expected Optional[true], but got %v (%v)", debug, err)
+	}
+}
@@ -0,0 +1,53 @@
+package optional
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrNilValue is returned, or panicked with by Of, for nil values under
+// ErrorOnNil.
+var ErrNilValue = errors.New("optional: value cannot be nil")
+
+// NilPolicy chooses how Of and OfPolicy handle a nil pointer.
+type NilPolicy int32
+
+const (
+	// PanicOnNil panics, failing fast. It is the default.
+	PanicOnNil NilPolicy = iota
+	// EmptyOnNil returns an empty Optional.
+	EmptyOnNil
+	// ErrorOnNil returns ErrNilValue from OfPolicy. Of, which cannot return
+	// an error, panics with ErrNilValue so that recovering code can tell it
+	// apart with errors.Is.
+	ErrorOnNil
+)
+
+// nilPolicy holds the NilPolicy used by Of.
+var nilPolicy atomic.Int32
+
+// SetNilPolicy sets the policy Of applies to nil pointers and returns the
+// previous one. Libraries can fail fast in development and stay lenient in
+// production:
+//
+//	if !debug {
+//		optional.SetNilPolicy(optional.EmptyOnNil)
+//	}
+func SetNilPolicy(p NilPolicy) NilPolicy {
+	return NilPolicy(nilPolicy.Swap(int32(p)))
+}
+
+// OfPolicy creates an Optional containing value, handling a nil pointer
+// according to policy instead of the policy set with SetNilPolicy.
+func OfPolicy[T any](value T, policy NilPolicy) (Optional[T], error) {
+	if !isNil(value) {
+		return Optional[T]{value: &value}, nil
+	}
+	switch policy {
+	case EmptyOnNil:
+		return Optional[T]{origin: captureOrigin()}, nil
+	case ErrorOnNil:
+		return Optional[T]{origin: captureOrigin()}, ErrNilValue
+	}
+	panic("Optional.OfPolicy: value cannot be nil")
+}
@@ -0,0 +1,43 @@
+package optional
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOfPolicy(t *testing.T) {
+	var p *int
+	if o, err := OfPolicy(p, EmptyOnNil); err != nil || o.IsPresent() {
+		t.Errorf("Expected an empty optional without error, but got %v, %v", o, err)
+	}
+	if o, err := OfPolicy(p, ErrorOnNil); !errors.Is(err, ErrNilValue) || o.IsPresent() {
+		t.Errorf("Expected ErrNilValue, but got %v, %v", o, err)
+	}
+	if o, err := OfPolicy(new(int), ErrorOnNil); err != nil || !o.IsPresent() {
+		t.Errorf("Expected a present optional, but got %v, %v", o, err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic under PanicOnNil, but did not panic")
+		}
+	}()
+	OfPolicy(p, PanicOnNil)
+}
+
+func TestSetNilPolicy(t *testing.T) {
+	if prev := SetNilPolicy(EmptyOnNil); prev != PanicOnNil {
+		t.Errorf("Expected the default policy to be PanicOnNil, but got %d", prev)
+	}
+	defer SetNilPolicy(PanicOnNil)
+	if o := Of[*int](nil); o.IsPresent() {
+		t.Errorf("Expected Of to return an empty optional under EmptyOnNil, but it was present")
+	}
+
+	SetNilPolicy(ErrorOnNil)
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrNilValue) {
+			t.Errorf("Expected Of to panic with ErrNilValue under ErrorOnNil, but got %v", err)
+		}
+	}()
+	Of[*int](nil)
+}
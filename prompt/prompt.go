@@ -0,0 +1,74 @@
+// Package prompt asks for optional input on a terminal. Skipping a
+// question, by entering an empty line or closing the input, yields an empty
+// Optional:
+//
+//	p := prompt.New(os.Stdin, os.Stderr)
+//	name, err := p.String("Name")
+//	port, err := prompt.Ask[int](p, "Port")
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hermann-craft/optional"
+)
+
+// Prompter asks questions on out and reads the answers from in.
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// New returns a Prompter reading from in and writing to out.
+func New(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{in: bufio.NewReader(in), out: out}
+}
+
+// String asks question and returns the answer with surrounding white space
+// removed.
+func (p *Prompter) String(question string) (optional.Optional[string], error) {
+	return AskFunc(p, question, func(s string) (string, error) { return s, nil })
+}
+
+// Ask asks question and parses the answer as a T, as Optional's
+// UnmarshalText does. An answer that does not parse is reported and the
+// question asked again.
+func Ask[T any](p *Prompter, question string) (optional.Optional[T], error) {
+	return AskFunc(p, question, func(s string) (T, error) {
+		var o optional.Optional[T]
+		err := o.UnmarshalText([]byte(s))
+		return o.OrElse(*new(T)), err
+	})
+}
+
+// AskFunc asks question and parses the answer with parse. An answer parse
+// rejects is reported and the question asked again.
+func AskFunc[T any](p *Prompter, question string, parse func(string) (T, error)) (optional.Optional[T], error) {
+	for {
+		if _, err := fmt.Fprintf(p.out, "%s: ", question); err != nil {
+			return optional.Empty[T](), err
+		}
+		line, err := p.in.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return optional.Empty[T](), err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return optional.Empty[T](), nil
+		}
+		v, perr := parse(line)
+		if perr == nil {
+			return optional.Of(v), nil
+		}
+		if _, werr := fmt.Fprintf(p.out, "invalid answer: %v\n", perr); werr != nil {
+			return optional.Empty[T](), werr
+		}
+		if errors.Is(err, io.EOF) {
+			return optional.Empty[T](), nil
+		}
+	}
+}
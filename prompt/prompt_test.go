@@ -0,0 +1,46 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestString(t *testing.T) {
+	var out strings.Builder
+	p := New(strings.NewReader("  Alice \n\n"), &out)
+	if got, err := p.String("Name"); err != nil || got.OrElse("") != "Alice" {
+		t.Errorf("Expected Optional[Alice], but got %v (%v)", got, err)
+	}
+	if got, err := p.String("Nickname"); err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v (%v)", got, err)
+	}
+	if got, err := p.String("Email"); err != nil || got.IsPresent() {
+		t.Errorf("Expected EOF to give an empty Optional, but got %v (%v)", got, err)
+	}
+	if out.String() != "Name: Nickname: Email: " {
+		t.Errorf("Expected the questions to be printed, but got %q", out.String())
+	}
+}
+
+func TestAsk(t *testing.T) {
+	var out strings.Builder
+	p := New(strings.NewReader("eighty\n8080\n5m"), &out)
+	if got, err := Ask[int](p, "Port"); err != nil || got.OrElse(0) != 8080 {
+		t.Errorf("Expected Optional[8080], but got %v (%v)", got, err)
+	}
+	if !strings.Contains(out.String(), "invalid answer") || strings.Count(out.String(), "Port: ") != 2 {
+		t.Errorf("Expected the question to be asked again, but got %q", out.String())
+	}
+	if got, err := Ask[time.Duration](p, "Timeout"); err != nil || got.OrElse(0) != 5*time.Minute {
+		t.Errorf("Expected Optional[5m0s], but got %v (%v)", got, err)
+	}
+}
+
+func TestAskInvalidAtEOF(t *testing.T) {
+	var out strings.Builder
+	p := New(strings.NewReader("x"), &out)
+	if got, err := Ask[int](p, "Port"); err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v (%v)", got, err)
+	}
+}
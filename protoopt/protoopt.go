@@ -0,0 +1,243 @@
+// Package protoopt translates between protobuf messages and Go structs with
+// Optional fields, for gateway services mapping REST DTOs to protos.
+//
+// Messages are read and written through the fields of the structs
+// protoc-gen-go generates, which it finds by their protobuf struct tags, so
+// the package does not import the protobuf module. Messages generated with
+// the opaque API, whose fields are unexported, and the members of oneofs
+// other than proto3 optional fields are not mapped.
+//
+// Struct fields are matched to message fields by json tag, which may name
+// either the proto field or its JSON name, or else by Go name ignoring case
+// and underscores. Presence carries over in both directions: a proto3
+// optional field, or any other field with explicit presence, maps to a
+// present Optional exactly when it is set, so protojson output of a message
+// built by ToMessage contains the same fields as the JSON encoding of the
+// struct.
+package protoopt
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hermann-craft/optional/reflectopt"
+)
+
+// FromMessage sets the Optional fields of the struct dst points to from the
+// matching fields of msg, a pointer to a generated message. Fields with
+// explicit presence that are not set become empty. Struct fields with no
+// matching message field are left alone.
+func FromMessage(dst, msg any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("protoopt: destination must be a pointer to a struct, got %T", dst)
+	}
+	m, err := message(msg)
+	if err != nil {
+		return err
+	}
+	return eachField(v.Elem(), m, func(name string, f reflect.Value, pf protoField) error {
+		scanner := f.Addr().Interface().(sql.Scanner)
+		mf := m.Field(pf.index)
+		if pf.presence && mf.IsNil() {
+			return scanner.Scan(nil)
+		}
+		if err := scanner.Scan(fromProto(mf, pf)); err != nil {
+			return fmt.Errorf("protoopt: field %s: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// ToMessage sets the fields of msg, a pointer to a generated message,
+// matching the Optional fields of the struct src, or of the struct src
+// points to. Fields matching an empty Optional are cleared.
+func ToMessage(msg, src any) error {
+	v := reflect.Indirect(reflect.ValueOf(src))
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("protoopt: source must be a struct, got %T", src)
+	}
+	m, err := message(msg)
+	if err != nil {
+		return err
+	}
+	return eachField(v, m, func(name string, f reflect.Value, pf protoField) error {
+		mf := m.Field(pf.index)
+		value, present := reflectopt.ValueOf(f)
+		if !present {
+			mf.SetZero()
+			return nil
+		}
+		pv, err := toProto(value, mf.Type(), pf)
+		if err != nil {
+			return fmt.Errorf("protoopt: field %s: %w", name, err)
+		}
+		mf.Set(pv)
+		return nil
+	})
+}
+
+// message returns the struct msg points to.
+func message(msg any) (reflect.Value, error) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("protoopt: message must be a pointer to a generated message struct, got %T", msg)
+	}
+	return v.Elem(), nil
+}
+
+// protoField describes a singular field of a generated message struct.
+type protoField struct {
+	index    int
+	name     string
+	jsonName string
+	// presence reports whether the field is set exactly when it is not nil.
+	presence bool
+	enum     bool
+}
+
+// messageFields returns the singular fields of the generated message struct
+// type t, read from their protobuf struct tags, which look like
+//
+//	protobuf:"bytes,2,opt,name=nick_name,json=nickName,proto3,oneof"
+func messageFields(t reflect.Type) []protoField {
+	var fields []protoField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("protobuf")
+		if !ok || !f.IsExported() {
+			continue
+		}
+		pf := protoField{index: i}
+		var repeated, proto3, oneof bool
+		for _, part := range strings.Split(tag, ",") {
+			key, value, _ := strings.Cut(part, "=")
+			switch key {
+			case "rep":
+				repeated = true
+			case "proto3":
+				proto3 = true
+			case "oneof":
+				oneof = true
+			case "name":
+				pf.name = value
+			case "json":
+				pf.jsonName = value
+			case "enum":
+				pf.enum = true
+			}
+		}
+		if repeated || pf.name == "" {
+			continue
+		}
+		if pf.jsonName == "" {
+			pf.jsonName = pf.name
+		}
+		// Scalars with presence and messages are pointers; bytes are a
+		// nil slice when a proto3 optional or proto2 field is not set.
+		pf.presence = f.Type.Kind() == reflect.Pointer || f.Type.Kind() == reflect.Slice && (oneof || !proto3)
+		fields = append(fields, pf)
+	}
+	return fields
+}
+
+// eachField calls fn for every Optional field of v matching a singular
+// field of the message m.
+func eachField(v, m reflect.Value, fn func(string, reflect.Value, protoField) error) error {
+	fields := messageFields(m.Type())
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || !reflectopt.IsOptionalType(f.Type) {
+			continue
+		}
+		pf, ok := matchingField(fields, f)
+		if !ok {
+			continue
+		}
+		if err := fn(f.Name, v.Field(i), pf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func matchingField(fields []protoField, f reflect.StructField) (protoField, bool) {
+	if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); name != "" && name != "-" {
+		for _, pf := range fields {
+			if pf.name == name {
+				return pf, true
+			}
+		}
+		for _, pf := range fields {
+			if pf.jsonName == name {
+				return pf, true
+			}
+		}
+	}
+	for _, pf := range fields {
+		if strings.EqualFold(strings.ReplaceAll(pf.name, "_", ""), f.Name) {
+			return pf, true
+		}
+	}
+	return protoField{}, false
+}
+
+// fromProto returns the value of the message field v in a form Scan
+// accepts.
+func fromProto(v reflect.Value, pf protoField) any {
+	if v.Kind() == reflect.Pointer && v.Elem().Kind() != reflect.Struct {
+		v = v.Elem()
+	}
+	if pf.enum {
+		return v.Int()
+	}
+	return v.Interface()
+}
+
+// convertScalar converts v to the scalar type target, allowing conversions
+// between integer types that keep the value and between floating-point
+// types that do not overflow.
+func convertScalar(v reflect.Value, target reflect.Type) (reflect.Value, bool) {
+	cv := reflect.New(target).Elem()
+	switch {
+	case v.Kind() == target.Kind() && v.Type().ConvertibleTo(target):
+		return v.Convert(target), true
+	case v.CanInt() && cv.CanInt() && !cv.OverflowInt(v.Int()):
+		cv.SetInt(v.Int())
+	case v.CanInt() && cv.CanUint() && v.Int() >= 0 && !cv.OverflowUint(uint64(v.Int())):
+		cv.SetUint(uint64(v.Int()))
+	case v.CanUint() && cv.CanUint() && !cv.OverflowUint(v.Uint()):
+		cv.SetUint(v.Uint())
+	case v.CanUint() && cv.CanInt() && v.Uint() <= 1<<63-1 && !cv.OverflowInt(int64(v.Uint())):
+		cv.SetInt(int64(v.Uint()))
+	case v.CanFloat() && cv.CanFloat() && !cv.OverflowFloat(v.Float()):
+		cv.SetFloat(v.Float())
+	default:
+		return reflect.Value{}, false
+	}
+	return cv, true
+}
+
+// toProto converts v to the type of a message field of type t.
+func toProto(v reflect.Value, t reflect.Type, pf protoField) (reflect.Value, error) {
+	switch {
+	case t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct:
+		if v.Type().AssignableTo(t) {
+			return v, nil
+		}
+	case t.Kind() == reflect.Pointer:
+		if cv, ok := convertScalar(v, t.Elem()); ok {
+			p := reflect.New(t.Elem())
+			p.Elem().Set(cv)
+			return p, nil
+		}
+	default:
+		if cv, ok := convertScalar(v, t); ok {
+			return cv, nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %s as proto field %s of type %s", v.Type(), pf.name, t)
+}
@@ -0,0 +1,132 @@
+package protoopt
+
+import (
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+// userRole, user and address mirror the code protoc-gen-go generates for
+//
+//	enum Role { ROLE_UNSPECIFIED = 0; ROLE_ADMIN = 1; }
+//	message User {
+//	  int64 id = 1;
+//	  optional string nick_name = 2;
+//	  optional int32 age = 3;
+//	  optional Role role = 4;
+//	  Address address = 5;
+//	  repeated string tags = 6;
+//	  optional bytes thumbnail = 7;
+//	  oneof contact { string email = 8; }
+//	}
+//	message Address { string city = 1; }
+type userRole int32
+
+type user struct {
+	Id        int64          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	NickName  *string        `protobuf:"bytes,2,opt,name=nick_name,json=nickName,proto3,oneof" json:"nick_name,omitempty"`
+	Age       *int32         `protobuf:"varint,3,opt,name=age,proto3,oneof" json:"age,omitempty"`
+	Role      *userRole      `protobuf:"varint,4,opt,name=role,proto3,enum=test.Role,oneof" json:"role,omitempty"`
+	Address   *address       `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	Tags      []string       `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Thumbnail []byte         `protobuf:"bytes,7,opt,name=thumbnail,proto3,oneof" json:"thumbnail,omitempty"`
+	Contact   isUser_Contact `protobuf_oneof:"contact"`
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type address struct {
+	City string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+}
+
+type role int32
+
+type userDTO struct {
+	ID        optional.Optional[int64]    `json:"id"`
+	NickName  optional.Optional[string]   `json:"nickName"`
+	Age       optional.Optional[int]      `json:"age"`
+	Role      optional.Optional[role]     `json:"role"`
+	Address   optional.Optional[*address] `json:"address"`
+	Tags      optional.Optional[[]string] `json:"tags"`
+	Thumbnail optional.Optional[[]byte]
+	Email     optional.Optional[string]
+	Internal  string
+}
+
+func TestRoundTrip(t *testing.T) {
+	in := userDTO{
+		ID:        optional.Of(int64(7)),
+		NickName:  optional.Of(""),
+		Role:      optional.Of(role(1)),
+		Address:   optional.Of(&address{City: "London"}),
+		Tags:      optional.Of([]string{"x"}),
+		Thumbnail: optional.Of([]byte{}),
+		Email:     optional.Of("a@b"),
+	}
+	var msg user
+	if err := ToMessage(&msg, in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if msg.Id != 7 || msg.NickName == nil || *msg.NickName != "" || msg.Age != nil {
+		t.Errorf("Expected id 7, an explicitly empty nick name and no age, but got %+v", msg)
+	}
+	if msg.Role == nil || *msg.Role != 1 || msg.Address.City != "London" || msg.Thumbnail == nil {
+		t.Errorf("Expected role 1, an address and an empty thumbnail, but got %+v", msg)
+	}
+	if msg.Tags != nil || msg.Contact != nil {
+		t.Errorf("Expected repeated fields and oneofs to be left alone, but got %+v", msg)
+	}
+
+	out := userDTO{Age: optional.Of(99)}
+	if err := FromMessage(&out, &msg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if out.ID.OrElse(0) != 7 || !out.NickName.IsPresent() || out.NickName.Get() != "" {
+		t.Errorf("Expected id 7 and an empty nick name, but got %v %v", out.ID, out.NickName)
+	}
+	if out.Age.IsPresent() {
+		t.Errorf("Expected an unset age to become empty, but got %v", out.Age)
+	}
+	if out.Role.OrElse(0) != 1 || out.Address.Get().City != "London" || !out.Thumbnail.IsPresent() {
+		t.Errorf("Expected role 1, an address and a thumbnail, but got %v %v %v", out.Role, out.Address, out.Thumbnail)
+	}
+}
+
+func TestToMessageClears(t *testing.T) {
+	var msg user
+	if err := ToMessage(&msg, userDTO{Age: optional.Of(3), Address: optional.Of(&address{})}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := ToMessage(&msg, &userDTO{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if msg.Age != nil || msg.Address != nil {
+		t.Errorf("Expected age and address to be cleared, but got %+v", msg)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	var msg user
+	if err := FromMessage(userDTO{}, &msg); err == nil {
+		t.Error("Expected an error for a non-pointer destination, but got nil")
+	}
+	if err := FromMessage(&userDTO{}, msg); err == nil {
+		t.Error("Expected an error for a non-pointer message, but got nil")
+	}
+	var bad struct {
+		Age optional.Optional[string]
+	}
+	bad.Age = optional.Of("old")
+	if err := ToMessage(&msg, bad); err == nil {
+		t.Error("Expected an error for a mismatched type, but got nil")
+	}
+	var big struct {
+		Age optional.Optional[int64]
+	}
+	big.Age = optional.Of(int64(1) << 40)
+	if err := ToMessage(&msg, big); err == nil {
+		t.Error("Expected an error for an overflowing value, but got nil")
+	}
+}
@@ -0,0 +1,49 @@
+// Package ptr converts between values, pointers and Optionals, so boundary
+// code between APIs that use nil pointers for absent values, such as
+// generated clients and SDKs, and code using Optional reads the same way
+// everywhere:
+//
+//	req.Limit = ptr.To(50)
+//	limit := ptr.Val(resp.Limit, 10)
+//	nickname := ptr.From(resp.Nickname)   // Optional[string]
+//	req.Nickname = ptr.FromOptional(nick) // *string
+//
+// From and FromOptional copy the value, so the Optional and the pointer
+// never alias each other.
+package ptr
+
+import "github.com/hermann-craft/optional"
+
+// To returns a pointer to a copy of v.
+func To[T any](v T) *T {
+	return &v
+}
+
+// Deref returns the value p points to, or the zero value of T if p is nil.
+func Deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// Val returns the value p points to, or def if p is nil.
+func Val[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// From returns an Optional holding a copy of the value p points to, or an
+// empty Optional if p is nil.
+func From[T any](p *T) optional.Optional[T] {
+	return optional.FromAWS(p)
+}
+
+// FromOptional returns a pointer to a copy of the value held by o, or nil
+// if o is empty.
+func FromOptional[T any](o optional.Optional[T]) *T {
+	return o.ToAWS()
+}
@@ -0,0 +1,50 @@
+package ptr
+
+import (
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+func TestTo(t *testing.T) {
+	p := To(42)
+	if p == nil || *p != 42 {
+		t.Errorf("Expected a pointer to 42, but got %v", p)
+	}
+}
+
+func TestDerefAndVal(t *testing.T) {
+	if got := Deref[int](nil); got != 0 {
+		t.Errorf("Expected 0, but got %d", got)
+	}
+	if got := Deref(To("a")); got != "a" {
+		t.Errorf("Expected a, but got %s", got)
+	}
+	if got := Val(nil, 10); got != 10 {
+		t.Errorf("Expected 10, but got %d", got)
+	}
+	if got := Val(To(5), 10); got != 5 {
+		t.Errorf("Expected 5, but got %d", got)
+	}
+}
+
+func TestFrom(t *testing.T) {
+	if From[string](nil).IsPresent() {
+		t.Errorf("Expected an empty Optional for nil, but it was present")
+	}
+	p := To("bob")
+	o := From(p)
+	*p = "alice"
+	if o.OrZero() != "bob" {
+		t.Errorf("Expected the Optional to hold a copy, but got %v", o)
+	}
+}
+
+func TestFromOptional(t *testing.T) {
+	if p := FromOptional(optional.Empty[int]()); p != nil {
+		t.Errorf("Expected nil, but got %v", *p)
+	}
+	if p := FromOptional(optional.Of(7)); p == nil || *p != 7 {
+		t.Errorf("Expected a pointer to 7, but got %v", p)
+	}
+}
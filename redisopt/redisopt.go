@@ -0,0 +1,80 @@
+// Package redisopt reads go-redis replies as Optionals, turning the
+// redis.Nil error of a missing key or hash field into an empty Optional so
+// that cache misses are handled explicitly and uniformly:
+//
+//	name, err := redisopt.String(rdb.Get(ctx, "user:1:name"))
+//	user, err := redisopt.JSON[User](rdb.HGet(ctx, "users", "1"))
+//
+// The functions take the *redis.StringCmd returned by commands such as Get,
+// HGet, GetDel and LIndex through the methods it implements, so the package
+// does not import go-redis itself. redis.Nil is recognised by its message,
+// "redis: nil", which go-redis has kept across major versions.
+package redisopt
+
+import (
+	"encoding/json"
+
+	"github.com/hermann-craft/optional"
+)
+
+// StringResult is implemented by *redis.StringCmd.
+type StringResult interface {
+	Result() (string, error)
+}
+
+// Int64Result is implemented by *redis.StringCmd and *redis.IntCmd.
+type Int64Result interface {
+	Int64() (int64, error)
+}
+
+// BytesResult is implemented by *redis.StringCmd.
+type BytesResult interface {
+	Bytes() ([]byte, error)
+}
+
+// String returns the reply of cmd, or an empty Optional if the key or field
+// does not exist.
+func String(cmd StringResult) (optional.Optional[string], error) {
+	return result(cmd.Result())
+}
+
+// Int64 returns the reply of cmd as an integer, or an empty Optional if the
+// key or field does not exist.
+func Int64(cmd Int64Result) (optional.Optional[int64], error) {
+	return result(cmd.Int64())
+}
+
+// Decode returns the reply of cmd decoded with unmarshal, such as
+// json.Unmarshal or proto.Unmarshal wrapped to take an any, or an empty
+// Optional if the key or field does not exist.
+func Decode[T any](cmd BytesResult, unmarshal func([]byte, any) error) (optional.Optional[T], error) {
+	data, err := result(cmd.Bytes())
+	if err != nil || data.IsEmpty() {
+		return optional.Empty[T](), err
+	}
+	var v T
+	if err := unmarshal(data.Get(), &v); err != nil {
+		return optional.Empty[T](), err
+	}
+	return optional.Of(v), nil
+}
+
+// JSON is Decode with json.Unmarshal.
+func JSON[T any](cmd BytesResult) (optional.Optional[T], error) {
+	return Decode[T](cmd, json.Unmarshal)
+}
+
+func result[T any](v T, err error) (optional.Optional[T], error) {
+	switch {
+	case isNil(err):
+		return optional.Empty[T](), nil
+	case err != nil:
+		return optional.Empty[T](), err
+	}
+	return optional.Of(v), nil
+}
+
+// isNil reports whether err is redis.Nil.
+func isNil(err error) bool {
+	return err != nil && err.Error() == "redis: nil"
+}
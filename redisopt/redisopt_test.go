@@ -0,0 +1,72 @@
+package redisopt
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// redisError mirrors proto.RedisError, the type of redis.Nil.
+type redisError string
+
+func (e redisError) Error() string { return string(e) }
+
+const redisNil = redisError("redis: nil")
+
+// stringCmd mirrors *redis.StringCmd.
+type stringCmd struct {
+	val string
+	err error
+}
+
+func (c *stringCmd) Result() (string, error) { return c.val, c.err }
+
+func (c *stringCmd) Bytes() ([]byte, error) { return []byte(c.val), c.err }
+
+func (c *stringCmd) Int64() (int64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	return strconv.ParseInt(c.val, 10, 64)
+}
+
+func TestString(t *testing.T) {
+	got, err := String(&stringCmd{val: "ada"})
+	if err != nil || got.OrZero() != "ada" {
+		t.Errorf("Expected Optional[ada], but got %v, %v", got, err)
+	}
+	got, err = String(&stringCmd{err: redisNil})
+	if err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional for a miss, but got %v, %v", got, err)
+	}
+	errDown := errors.New("connection refused")
+	if _, err := String(&stringCmd{err: errDown}); err != errDown {
+		t.Errorf("Expected %v, but got %v", errDown, err)
+	}
+}
+
+func TestInt64(t *testing.T) {
+	got, err := Int64(&stringCmd{val: "42"})
+	if err != nil || got.OrZero() != 42 {
+		t.Errorf("Expected Optional[42], but got %v, %v", got, err)
+	}
+	if got, err := Int64(&stringCmd{err: redisNil}); err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional for a miss, but got %v, %v", got, err)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	got, err := JSON[user](&stringCmd{val: `{"name":"ada"}`})
+	if err != nil || got.OrZero().Name != "ada" {
+		t.Errorf("Expected a user named ada, but got %v, %v", got, err)
+	}
+	if got, err := JSON[user](&stringCmd{err: redisNil}); err != nil || got.IsPresent() {
+		t.Errorf("Expected an empty Optional for a miss, but got %v, %v", got, err)
+	}
+	if _, err := JSON[user](&stringCmd{val: "{"}); err == nil {
+		t.Errorf("Expected a decoding error, but got nil")
+	}
+}
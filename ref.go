@@ -0,0 +1,168 @@
+package optional
+
+import "sync"
+
+// Ref is a mutable slot that may or may not hold a value, the in-place
+// counterpart of the immutable Optional. The zero Ref is empty. A Ref must
+// not be copied after first use; it is not safe for concurrent use.
+type Ref[T any] struct {
+	value *T
+}
+
+// NewRef returns a Ref holding the value of o, if any.
+func NewRef[T any](o Optional[T]) *Ref[T] {
+	r := &Ref[T]{}
+	if o.value != nil {
+		r.Set(*o.value)
+	}
+	return r
+}
+
+// Optional returns a snapshot of the current value.
+func (r *Ref[T]) Optional() Optional[T] {
+	if r.value == nil {
+		return Empty[T]()
+	}
+	v := *r.value
+	return Optional[T]{value: &v}
+}
+
+// IsPresent returns true if the Ref holds a value.
+func (r *Ref[T]) IsPresent() bool {
+	return r.value != nil
+}
+
+// Set stores v.
+func (r *Ref[T]) Set(v T) {
+	r.value = &v
+}
+
+// Clear empties the Ref.
+func (r *Ref[T]) Clear() {
+	r.value = nil
+}
+
+// Take returns the current value and empties the Ref.
+func (r *Ref[T]) Take() Optional[T] {
+	o := r.Optional()
+	r.value = nil
+	return o
+}
+
+// Replace stores v and returns the previous value.
+func (r *Ref[T]) Replace(v T) Optional[T] {
+	o := r.Optional()
+	r.Set(v)
+	return o
+}
+
+// GetOrInsert stores v if the Ref is empty and returns a pointer to the
+// stored value, through which it may be modified in place.
+func (r *Ref[T]) GetOrInsert(v T) *T {
+	if r.value == nil {
+		r.Set(v)
+	}
+	return r.value
+}
+
+// SetIfEmpty stores v if the Ref is empty and reports whether it did.
+func (r *Ref[T]) SetIfEmpty(v T) bool {
+	return r.SetIf(Optional[T].IsEmpty, v)
+}
+
+// SetIf stores v if pred returns true for the current value and reports
+// whether it did.
+func (r *Ref[T]) SetIf(pred func(old Optional[T]) bool, v T) bool {
+	if !pred(r.Optional()) {
+		return false
+	}
+	r.Set(v)
+	return true
+}
+
+// String returns a string representation of the current value.
+func (r *Ref[T]) String() string {
+	return r.Optional().String()
+}
+
+// Swap exchanges the contents of a and b.
+func Swap[T any](a, b *Ref[T]) {
+	a.value, b.value = b.value, a.value
+}
+
+// Swapped returns a and b in exchanged order, the value counterpart of Swap.
+func Swapped[T any](a, b Optional[T]) (Optional[T], Optional[T]) {
+	return b, a
+}
+
+// SyncRef is a Ref that is safe for concurrent use. Each method is atomic,
+// so SetIfEmpty gives "first writer wins" initialization. The zero SyncRef
+// is empty; it must not be copied after first use. SyncRef has no
+// GetOrInsert, as the returned pointer could not be used safely.
+type SyncRef[T any] struct {
+	mu  sync.Mutex
+	ref Ref[T]
+}
+
+// Optional returns a snapshot of the current value.
+func (r *SyncRef[T]) Optional() Optional[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ref.Optional()
+}
+
+// IsPresent returns true if the SyncRef holds a value.
+func (r *SyncRef[T]) IsPresent() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ref.IsPresent()
+}
+
+// Set stores v.
+func (r *SyncRef[T]) Set(v T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ref.Set(v)
+}
+
+// Clear empties the SyncRef.
+func (r *SyncRef[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ref.Clear()
+}
+
+// Take returns the current value and empties the SyncRef.
+func (r *SyncRef[T]) Take() Optional[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ref.Take()
+}
+
+// Replace stores v and returns the previous value.
+func (r *SyncRef[T]) Replace(v T) Optional[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ref.Replace(v)
+}
+
+// SetIfEmpty stores v if the SyncRef is empty and reports whether it did.
+func (r *SyncRef[T]) SetIfEmpty(v T) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ref.SetIfEmpty(v)
+}
+
+// SetIf stores v if pred returns true for the current value and reports
+// whether it did. The SyncRef is locked while pred runs, so pred must not
+// use it.
+func (r *SyncRef[T]) SetIf(pred func(old Optional[T]) bool, v T) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ref.SetIf(pred, v)
+}
+
+// String returns a string representation of the current value.
+func (r *SyncRef[T]) String() string {
+	return r.Optional().String()
+}
@@ -0,0 +1,106 @@
+package optional
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRef(t *testing.T) {
+	var r Ref[int]
+	if r.IsPresent() || r.Optional().IsPresent() {
+		t.Errorf("Expected the zero Ref to be empty, but got %v", &r)
+	}
+	r.Set(1)
+	if got := r.Optional(); got.OrElse(0) != 1 {
+		t.Errorf("Expected Optional[1], but got %v", got)
+	}
+	if prev := r.Replace(2); prev.OrElse(0) != 1 || r.Optional().OrElse(0) != 2 {
+		t.Errorf("Expected Replace to return 1 and store 2, but got %v and %v", prev, &r)
+	}
+	if got := r.Take(); got.OrElse(0) != 2 || r.IsPresent() {
+		t.Errorf("Expected Take to return 2 and empty the Ref, but got %v and %v", got, &r)
+	}
+	if prev := r.Replace(3); prev.IsPresent() {
+		t.Errorf("Expected an empty previous value, but got %v", prev)
+	}
+	r.Clear()
+	if r.IsPresent() || r.String() != "Optional.empty" {
+		t.Errorf("Expected an empty Ref, but got %v", &r)
+	}
+}
+
+func TestRefGetOrInsert(t *testing.T) {
+	r := NewRef(Empty[[]string]())
+	p := r.GetOrInsert(nil)
+	*p = append(*p, "a")
+	*r.GetOrInsert([]string{"ignored"}) = append(*r.GetOrInsert(nil), "b")
+	if got := r.Optional().Get(); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("Expected [a b], but got %v", got)
+	}
+}
+
+func TestRefSnapshot(t *testing.T) {
+	r := NewRef(Of(1))
+	snapshot := r.Optional()
+	*r.GetOrInsert(0) = 5
+	if snapshot.Get() != 1 || r.Optional().Get() != 5 {
+		t.Errorf("Expected the snapshot to stay 1 and the Ref to be 5, but got %v and %v", snapshot, r)
+	}
+}
+
+func TestRefSetIf(t *testing.T) {
+	var r Ref[int]
+	if !r.SetIfEmpty(1) || r.SetIfEmpty(2) || r.Optional().Get() != 1 {
+		t.Errorf("Expected only the first SetIfEmpty to succeed, but got %v", &r)
+	}
+	less := func(n int) func(Optional[int]) bool {
+		return func(old Optional[int]) bool { return old.OrElse(0) < n }
+	}
+	if !r.SetIf(less(5), 5) || r.SetIf(less(5), 3) || r.Optional().Get() != 5 {
+		t.Errorf("Expected Optional[5], but got %v", &r)
+	}
+}
+
+func TestSwap(t *testing.T) {
+	a, b := NewRef(Of(1)), NewRef(Empty[int]())
+	Swap(a, b)
+	if a.IsPresent() || b.Optional().OrElse(0) != 1 {
+		t.Errorf("Expected empty and 1, but got %v and %v", a, b)
+	}
+	x, y := Swapped(Of(1), Of(2))
+	if x.Get() != 2 || y.Get() != 1 {
+		t.Errorf("Expected 2 and 1, but got %v and %v", x, y)
+	}
+}
+
+func TestSyncRef(t *testing.T) {
+	var r SyncRef[int]
+	var wg sync.WaitGroup
+	var winners atomic.Int32
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if r.SetIfEmpty(i) {
+				winners.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if winners.Load() != 1 || !r.IsPresent() {
+		t.Errorf("Expected exactly one winner, but got %d", winners.Load())
+	}
+	if prev := r.Replace(-1); prev.IsEmpty() || r.Take().Get() != -1 || r.IsPresent() {
+		t.Errorf("Expected Replace and Take to round-trip, but got %v", &r)
+	}
+	r.Set(1)
+	r.SetIf(func(old Optional[int]) bool { return old.Get() == 1 }, 2)
+	if r.String() != "Optional[2]" {
+		t.Errorf("Expected Optional[2], but got %v", &r)
+	}
+	r.Clear()
+	if r.Optional().IsPresent() {
+		t.Errorf("Expected an empty SyncRef, but got %v", &r)
+	}
+}
@@ -0,0 +1,133 @@
+package optional
+
+import (
+	"reflect"
+	"strings"
+)
+
+// reflected is implemented by every Optional and gives the reflective
+// helpers of this package access to its contents without knowing T.
+type reflected interface {
+	reflectValue() (reflect.Value, bool)
+	elemType() reflect.Type
+}
+
+// reflectSetter is implemented by every *Optional.
+type reflectSetter interface {
+	setReflect(v reflect.Value)
+}
+
+var reflectedType = reflect.TypeFor[reflected]()
+
+// reflectValue returns an addressable copy of the value and whether it is present.
+func (o Optional[T]) reflectValue() (reflect.Value, bool) {
+	if o.IsEmpty() {
+		return reflect.Value{}, false
+	}
+	value := *o.value
+	return reflect.ValueOf(&value).Elem(), true
+}
+
+func (o Optional[T]) elemType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// setReflect replaces the contents of o with v, which must be assignable to
+// T. An invalid or nil v empties o.
+func (o *Optional[T]) setReflect(v reflect.Value) {
+	if !v.IsValid() {
+		*o = Empty[T]()
+		return
+	}
+	var value T
+	reflect.ValueOf(&value).Elem().Set(v)
+	if isNil(value) {
+		*o = Empty[T]()
+		return
+	}
+	*o = Optional[T]{value: &value}
+}
+
+// isOptionalType reports whether t is an Optional, or a type embedding one.
+func isOptionalType(t reflect.Type) bool {
+	return t.Implements(reflectedType)
+}
+
+// optionalElem returns T for the Optional type t.
+func optionalElem(t reflect.Type) reflect.Type {
+	return reflect.Zero(t).Interface().(reflected).elemType()
+}
+
+// optionalValue returns the contents of the Optional v.
+func optionalValue(v reflect.Value) (reflect.Value, bool) {
+	return v.Interface().(reflected).reflectValue()
+}
+
+// setOptional sets the addressable Optional dst to v.
+func setOptional(dst, v reflect.Value) {
+	dst.Addr().Interface().(reflectSetter).setReflect(v)
+}
+
+// convertTo returns v as a value of type t when it is assignable to t or
+// differs from it only by name.
+func convertTo(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if v.Type().AssignableTo(t) {
+		return v, true
+	}
+	if v.Kind() == t.Kind() && v.Type().ConvertibleTo(t) {
+		return v.Convert(t), true
+	}
+	return reflect.Value{}, false
+}
+
+// indirect follows pointers from v, returning an invalid value for nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// matchingField returns the exported field of the struct dst with the same
+// name as f, or failing that the same json tag name.
+func matchingField(dst reflect.Value, f reflect.StructField) (reflect.Value, bool) {
+	t := dst.Type()
+	if df, ok := t.FieldByName(f.Name); ok && df.IsExported() {
+		if v, err := dst.FieldByIndexErr(df.Index); err == nil {
+			return v, true
+		}
+	}
+	name := jsonName(f)
+	if name == "" {
+		return reflect.Value{}, false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if df := t.Field(i); df.IsExported() && jsonName(df) == name {
+			return dst.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// hasTagOption reports whether the optional tag of f lists option, as in
+// `optional:"required"`.
+func hasTagOption(f reflect.StructField, option string) bool {
+	for _, o := range strings.Split(f.Tag.Get("optional"), ",") {
+		if strings.TrimSpace(o) == option {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonName returns the name given to f by its json tag, if any.
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
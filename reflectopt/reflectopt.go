@@ -0,0 +1,107 @@
+// Package reflectopt lets encoders, ORMs and binders support Optional
+// fields generically, through reflection, without knowing the type of the
+// value they hold:
+//
+//	if reflectopt.IsOptionalType(field.Type()) {
+//		if v, ok := reflectopt.ValueOf(field); ok {
+//			encode(v)
+//		}
+//	}
+//
+// An Optional type is recognised by its IsPresent and Get methods and the
+// Scan method of its pointer, so types embedding an Optional, such as
+// optional.String, count as Optionals too.
+package reflectopt
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	valuerType  = reflect.TypeFor[driver.Valuer]()
+)
+
+// IsOptionalType reports whether t is an Optional type.
+func IsOptionalType(t reflect.Type) bool {
+	return ElemType(t) != nil
+}
+
+// ElemType returns the type of the value held by the Optional type t, or
+// nil if t is not an Optional type.
+func ElemType(t reflect.Type) reflect.Type {
+	isPresent, ok := t.MethodByName("IsPresent")
+	if !ok || isPresent.Type.NumIn() != 1 || isPresent.Type.NumOut() != 1 || isPresent.Type.Out(0).Kind() != reflect.Bool {
+		return nil
+	}
+	get, ok := t.MethodByName("Get")
+	if !ok || get.Type.NumIn() != 1 || get.Type.NumOut() != 1 {
+		return nil
+	}
+	if !reflect.PointerTo(t).Implements(scannerType) {
+		return nil
+	}
+	return get.Type.Out(0)
+}
+
+// ValueOf returns the value held by the Optional v and true, or an invalid
+// Value and false if v is empty. It panics if v is not an Optional.
+func ValueOf(v reflect.Value) (reflect.Value, bool) {
+	mustBeOptional(v.Type())
+	if !v.MethodByName("IsPresent").Call(nil)[0].Bool() {
+		return reflect.Value{}, false
+	}
+	return v.MethodByName("Get").Call(nil)[0], true
+}
+
+// SetValue sets the addressable Optional dst to v, or empties it if v is
+// invalid or a nil pointer, map, slice or interface. v must be assignable
+// to the element type of dst; values of an element type implementing both
+// sql.Scanner and driver.Valuer, such as decimal.Decimal, are stored
+// through their driver value. It panics if dst is not an addressable
+// Optional or v cannot be stored.
+func SetValue(dst, v reflect.Value) {
+	elem := mustBeOptional(dst.Type())
+	if !dst.CanAddr() {
+		panic("reflectopt: SetValue of unaddressable " + dst.Type().String())
+	}
+	var src any
+	if v.IsValid() && !isNil(v) {
+		if !v.Type().AssignableTo(elem) {
+			panic(fmt.Sprintf("reflectopt: %s is not assignable to %s", v.Type(), elem))
+		}
+		src = v.Interface()
+		if reflect.PointerTo(elem).Implements(scannerType) && elem.Implements(valuerType) {
+			dv, err := v.Interface().(driver.Valuer).Value()
+			if err != nil {
+				panic(fmt.Sprintf("reflectopt: %s: %v", dst.Type(), err))
+			}
+			src = dv
+		}
+	}
+	if err := dst.Addr().Interface().(sql.Scanner).Scan(src); err != nil {
+		panic(fmt.Sprintf("reflectopt: %s: %v", dst.Type(), err))
+	}
+}
+
+// mustBeOptional returns the element type of the Optional type t, and
+// panics if t is not one.
+func mustBeOptional(t reflect.Type) reflect.Type {
+	elem := ElemType(t)
+	if elem == nil {
+		panic("reflectopt: " + t.String() + " is not an Optional")
+	}
+	return elem
+}
+
+// isNil reports whether v is a nil pointer, map, slice or interface.
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package reflectopt
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+// amount is stored through its driver value, like decimal.Decimal.
+type amount struct {
+	cents int64
+}
+
+func (a *amount) Scan(src any) error {
+	var units, cents int64
+	if _, err := fmt.Sscanf(fmt.Sprint(src), "%d.%d", &units, &cents); err != nil {
+		return err
+	}
+	a.cents = units*100 + cents
+	return nil
+}
+
+func (a amount) Value() (driver.Value, error) {
+	return fmt.Sprintf("%d.%02d", a.cents/100, a.cents%100), nil
+}
+
+type profile struct {
+	Name    optional.Optional[string]
+	Born    optional.Optional[time.Time]
+	Balance optional.Optional[amount]
+	Tags    optional.Optional[[]string]
+	Title   optional.String
+	Age     int
+}
+
+func TestIsOptionalType(t *testing.T) {
+	typ := reflect.TypeFor[profile]()
+	for name, want := range map[string]reflect.Type{
+		"Name":    reflect.TypeFor[string](),
+		"Balance": reflect.TypeFor[amount](),
+		"Title":   reflect.TypeFor[string](),
+		"Age":     nil,
+	} {
+		f, _ := typ.FieldByName(name)
+		if got := ElemType(f.Type); got != want {
+			t.Errorf("Expected the element type of %s to be %v, but got %v", name, want, got)
+		}
+		if IsOptionalType(f.Type) != (want != nil) {
+			t.Errorf("Expected IsOptionalType(%s) to be %v, but it was not", name, want != nil)
+		}
+	}
+}
+
+func TestValueOf(t *testing.T) {
+	p := profile{Name: optional.Of("Ada")}
+	v := reflect.ValueOf(p)
+	if got, ok := ValueOf(v.FieldByName("Name")); !ok || got.Interface() != "Ada" {
+		t.Errorf("Expected Ada, but got %v, %v", got, ok)
+	}
+	if _, ok := ValueOf(v.FieldByName("Born")); ok {
+		t.Errorf("Expected an empty Optional, but got a value")
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	var p profile
+	v := reflect.ValueOf(&p).Elem()
+	born := time.Date(1815, 12, 10, 0, 0, 0, 0, time.UTC)
+	SetValue(v.FieldByName("Name"), reflect.ValueOf("Ada"))
+	SetValue(v.FieldByName("Born"), reflect.ValueOf(born))
+	SetValue(v.FieldByName("Balance"), reflect.ValueOf(amount{cents: 1250}))
+	SetValue(v.FieldByName("Tags"), reflect.ValueOf([]string{"math"}))
+	SetValue(v.FieldByName("Title"), reflect.ValueOf("Countess"))
+	if p.Name.OrZero() != "Ada" || !p.Born.OrZero().Equal(born) || p.Balance.OrZero().cents != 1250 || p.Tags.OrZero()[0] != "math" || p.Title.OrZero() != "Countess" {
+		t.Errorf("Expected every field to be set, but got %+v", p)
+	}
+
+	SetValue(v.FieldByName("Name"), reflect.Value{})
+	SetValue(v.FieldByName("Tags"), reflect.ValueOf([]string(nil)))
+	if p.Name.IsPresent() || p.Tags.IsPresent() {
+		t.Errorf("Expected the fields to be emptied, but got %v and %v", p.Name, p.Tags)
+	}
+}
+
+func TestSetValuePanics(t *testing.T) {
+	var p profile
+	v := reflect.ValueOf(&p).Elem()
+	for name, f := range map[string]func(){
+		"not optional":  func() { SetValue(v.FieldByName("Age"), reflect.ValueOf(1)) },
+		"unaddressable": func() { SetValue(reflect.ValueOf(p).FieldByName("Name"), reflect.ValueOf("x")) },
+		"wrong type":    func() { SetValue(v.FieldByName("Name"), reflect.ValueOf(1)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected SetValue to panic for %s, but it did not", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
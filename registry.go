@@ -0,0 +1,72 @@
+package optional
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// defaults maps a reflect.Type to the default registered for it.
+var defaults sync.Map
+
+// formatters maps a reflect.Type to the formatter registered for it.
+var formatters sync.Map
+
+// copiers maps a reflect.Type to the copy function registered for it.
+var copiers sync.Map
+
+// RegisterDefault registers v as the application-wide default for T, used by
+// OrDefault. Registering again replaces the previous default. Distinct named
+// types have distinct defaults:
+//
+//	type PageSize int
+//
+//	optional.RegisterDefault[PageSize](50)
+func RegisterDefault[T any](v T) {
+	defaults.Store(reflect.TypeFor[T](), v)
+}
+
+// OrDefault returns the value if present, otherwise the default registered
+// for T with RegisterDefault, or the zero value of T if there is none.
+func (o Optional[T]) OrDefault() T {
+	if o.value != nil {
+		return *o.value
+	}
+	if v, ok := defaults.Load(reflect.TypeFor[T]()); ok {
+		return v.(T)
+	}
+	var zero T
+	return zero
+}
+
+// RegisterFormatter registers format as the textual representation of
+// values of type T, used by String and therefore by fmt's %v and %s verbs,
+// so that sensitive or verbose values are printed the same way everywhere:
+//
+//	optional.RegisterFormatter(func(t Token) string { return "***" })
+//
+// Registering again replaces the previous formatter.
+func RegisterFormatter[T any](format func(T) string) {
+	formatters.Store(reflect.TypeFor[T](), format)
+}
+
+// Format returns the textual representation of v that String uses: the
+// result of the formatter registered for T, or fmt.Sprint(v) if there is
+// none.
+func Format[T any](v T) string {
+	if f, ok := formatters.Load(reflect.TypeFor[T]()); ok {
+		return f.(func(T) string)(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// RegisterCopier registers copy as the function CopyOf uses to copy values
+// of type T, for types whose contents it cannot copy by itself, such as
+// structs with unexported reference fields:
+//
+//	optional.RegisterCopier(func(b *Buffer) *Buffer { return b.Clone() })
+//
+// Registering again replaces the previous copy function.
+func RegisterCopier[T any](copy func(T) T) {
+	copiers.Store(reflect.TypeFor[T](), copy)
+}
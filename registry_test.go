@@ -0,0 +1,48 @@
+package optional
+
+import (
+	"fmt"
+	"testing"
+)
+
+type pageSize int
+
+type unregistered string
+
+type token string
+
+func TestOrDefault(t *testing.T) {
+	RegisterDefault[pageSize](50)
+	if got := Empty[pageSize]().OrDefault(); got != 50 {
+		t.Errorf("Expected 50, but got %v", got)
+	}
+	if got := Of[pageSize](10).OrDefault(); got != 10 {
+		t.Errorf("Expected 10, but got %v", got)
+	}
+	RegisterDefault[pageSize](25)
+	if got := Empty[pageSize]().OrDefault(); got != 25 {
+		t.Errorf("Expected 25, but got %v", got)
+	}
+	if got := Empty[unregistered]().OrDefault(); got != "" {
+		t.Errorf("Expected the zero value, but got %q", got)
+	}
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	if got := Of[token]("s3cr3t").String(); got != "Optional[s3cr3t]" {
+		t.Errorf("Expected Optional[s3cr3t], but got %s", got)
+	}
+	RegisterFormatter(func(token) string { return "***" })
+	if got := Of[token]("s3cr3t").String(); got != "Optional[***]" {
+		t.Errorf("Expected Optional[***], but got %s", got)
+	}
+	if got := fmt.Sprintf("%v", Of[token]("s3cr3t")); got != "Optional[***]" {
+		t.Errorf("Expected Optional[***], but got %s", got)
+	}
+	if got := Empty[token]().String(); got != "Optional.empty" {
+		t.Errorf("Expected Optional.empty, but got %s", got)
+	}
+	if got := Of[unregistered]("plain").String(); got != "Optional[plain]" {
+		t.Errorf("Expected Optional[plain], but got %s", got)
+	}
+}
@@ -0,0 +1,120 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CheckRequired verifies that the named fields of the struct v, or of the
+// struct v points to, hold a value, as well as every field tagged
+// `optional:"required"`, including those of nested structs. Fields are named
+// by Go name, with dotted paths for nested structs. An Optional field holds a
+// value when present, a Nullable when set and not null and a pointer when not
+// nil; other fields always do.
+//
+// The returned error joins a *MissingFieldError for each missing field.
+func CheckRequired(v any, fields ...string) error {
+	rv := indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("optional: CheckRequired needs a struct, got %T", v)
+	}
+	var errs []error
+	for _, name := range fields {
+		f, ok := fieldByPath(rv, name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("optional: %s has no field %s", rv.Type(), name))
+			continue
+		}
+		if !f.IsValid() || !holdsValue(f) {
+			errs = append(errs, &MissingFieldError{Field: name})
+		}
+	}
+	errs = append(errs, checkRequiredTags(rv, "")...)
+	return errors.Join(errs...)
+}
+
+func checkRequiredTags(v reflect.Value, path string) []error {
+	var errs []error
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		if hasTagOption(f, "required") && !holdsValue(fv) {
+			errs = append(errs, &MissingFieldError{Field: path + f.Name})
+			continue
+		}
+		if fv, ok := fieldValue(fv); ok && fv.Kind() == reflect.Struct && !isNullableType(fv.Type()) {
+			errs = append(errs, checkRequiredTags(fv, path+f.Name+".")...)
+		}
+	}
+	return errs
+}
+
+// fieldByPath returns the exported field of the struct v named by the dotted
+// path, following pointers and Optionals along the way. The returned value is
+// invalid if a pointer or Optional along the path holds no value.
+func fieldByPath(v reflect.Value, path string) (reflect.Value, bool) {
+	for {
+		name, rest, nested := strings.Cut(path, ".")
+		f, ok := v.Type().FieldByName(name)
+		if !ok || !f.IsExported() {
+			return reflect.Value{}, false
+		}
+		fv, err := v.FieldByIndexErr(f.Index)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		if !nested {
+			return fv, true
+		}
+		if v, ok = fieldValue(fv); !ok {
+			return reflect.Value{}, true
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		path = rest
+	}
+}
+
+// holdsValue reports whether the field v holds a value.
+func holdsValue(v reflect.Value) bool {
+	if isNullableType(v.Type()) {
+		set, _, present := v.Interface().(reflectedNullable).nullableValue()
+		return set && present
+	}
+	_, ok := fieldValue(v)
+	return ok
+}
+
+// NamedOptional pairs the presence of an Optional with a name for Require.
+type NamedOptional struct {
+	Name    string
+	present bool
+}
+
+// N names the Optional o for Require.
+func N[T any](name string, o Optional[T]) NamedOptional {
+	return NamedOptional{Name: name, present: o.IsPresent()}
+}
+
+// Require returns an error joining a *MissingFieldError for every empty
+// Optional among pairs, or nil if all are present:
+//
+//	if err := optional.Require(optional.N("email", email), optional.N("age", age)); err != nil {
+//		return err
+//	}
+func Require(pairs ...NamedOptional) error {
+	var errs []error
+	for _, p := range pairs {
+		if !p.present {
+			errs = append(errs, &MissingFieldError{Field: p.Name})
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,85 @@
+package optional
+
+import (
+	"errors"
+	"testing"
+)
+
+type requiredAddress struct {
+	City Optional[string] `optional:"required"`
+	Zip  Optional[string]
+}
+
+type requiredUser struct {
+	Name    Optional[string] `optional:"required"`
+	Email   Optional[string]
+	Phone   Nullable[string] `optional:"required"`
+	Manager *requiredUser
+	Address Optional[requiredAddress]
+	Note    string
+}
+
+func missingFields(err error) []string {
+	var fields []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var missing *MissingFieldError
+		if errors.As(e, &missing) {
+			fields = append(fields, missing.Field)
+		}
+	}
+	return fields
+}
+
+func TestCheckRequired(t *testing.T) {
+	u := requiredUser{Name: Of("Alice"), Phone: NullableOf("555")}
+	if err := CheckRequired(u); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+
+	u = requiredUser{Phone: Null[string](), Address: Of(requiredAddress{})}
+	err := CheckRequired(&u, "Email", "Manager", "Address.Zip", "Note")
+	if err == nil {
+		t.Fatal("Expected an error, but got nil")
+	}
+	want := []string{"Email", "Manager", "Address.Zip", "Name", "Phone", "Address.City"}
+	if got := missingFields(err); len(got) != len(want) {
+		t.Errorf("Expected %v, but got %v", want, got)
+	} else {
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("Expected %v, but got %v", want, got)
+				break
+			}
+		}
+	}
+
+	u = requiredUser{Name: Of("Alice"), Phone: NullableOf("555")}
+	if got := missingFields(CheckRequired(u, "Address.City")); len(got) != 1 || got[0] != "Address.City" {
+		t.Errorf("Expected [Address.City], but got %v", got)
+	}
+}
+
+func TestCheckRequiredErrors(t *testing.T) {
+	if err := CheckRequired(42); err == nil {
+		t.Error("Expected an error for a non-struct, but got nil")
+	}
+	u := requiredUser{Name: Of("Alice"), Phone: NullableOf("555")}
+	if err := CheckRequired(u, "Missing", "Name.Length"); err == nil {
+		t.Error("Expected an error for unknown fields, but got nil")
+	} else if len(missingFields(err)) != 0 {
+		t.Errorf("Expected no missing fields, but got %v", missingFields(err))
+	}
+}
+
+func TestRequire(t *testing.T) {
+	if err := Require(N("email", Of("a@b")), N("age", Of(30))); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	err := Require(N("email", Empty[string]()), N("age", Of(30)), N("name", Empty[string]()))
+	if got := missingFields(err); len(got) != 2 || got[0] != "email" || got[1] != "name" {
+		t.Errorf("Expected [email name], but got %v", got)
+	}
+	if err := Require(); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+}
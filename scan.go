@@ -0,0 +1,70 @@
+package optional
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ScanStruct scans the current row of rows into the struct dest points to,
+// matching each column to the field tagged with its name in a db tag, or
+// else to the field with the same name ignoring case. Fields tagged "-"
+// are skipped.
+//
+// NULL columns leave Optional fields empty, set pointer fields to nil and
+// fail for other fields, so nullable columns are declared as Optionals:
+//
+//	for rows.Next() {
+//		var u User // Nickname optional.Optional[string] `db:"nickname"`
+//		if err := optional.ScanStruct(rows, &u); err != nil {
+//			return err
+//		}
+//	}
+//
+// Every column must match a field.
+func ScanStruct[T any](rows *sql.Rows, dest *T) error {
+	v := reflect.ValueOf(dest).Elem()
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("optional: ScanStruct destination must point to a struct, got %T", dest)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("optional: %w", err)
+	}
+	fields := columnFields(v.Type())
+	targets := make([]any, len(columns))
+	for i, column := range columns {
+		index, ok := fields[strings.ToLower(column)]
+		if !ok {
+			return fmt.Errorf("optional: no field of %s for column %q", v.Type(), column)
+		}
+		targets[i] = v.FieldByIndex(index).Addr().Interface()
+	}
+	if err := rows.Scan(targets...); err != nil {
+		return fmt.Errorf("optional: %w", err)
+	}
+	return nil
+}
+
+// columnFields maps the lower-case column names of the fields of the struct
+// type t, including promoted ones, to their indexes.
+func columnFields(t reflect.Type) map[string][]int {
+	fields := map[string][]int{}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous && f.Type.Kind() == reflect.Struct && !isOptionalType(f.Type) {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("db"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		if _, ok := fields[strings.ToLower(name)]; !ok {
+			fields[strings.ToLower(name)] = f.Index
+		}
+	}
+	return fields
+}
@@ -0,0 +1,131 @@
+package optional
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scanDriver serves a single result set, given as the query text: a line of
+// comma-separated column names followed by the rows of values, with NULL
+// for nil. Every value is a string.
+type scanDriver struct{}
+
+func (scanDriver) Open(string) (driver.Conn, error) { return scanConn{}, nil }
+
+type scanConn struct{}
+
+func (scanConn) Prepare(query string) (driver.Stmt, error) { return scanStmt(query), nil }
+func (scanConn) Close() error                              { return nil }
+func (scanConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type scanStmt string
+
+func (scanStmt) Close() error                               { return nil }
+func (scanStmt) NumInput() int                              { return 0 }
+func (scanStmt) Exec([]driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (s scanStmt) Query([]driver.Value) (driver.Rows, error) {
+	lines := strings.Split(string(s), "\n")
+	return &scanRows{columns: strings.Split(lines[0], ","), rows: lines[1:]}, nil
+}
+
+type scanRows struct {
+	columns []string
+	rows    []string
+}
+
+func (r *scanRows) Columns() []string { return r.columns }
+func (r *scanRows) Close() error      { return nil }
+func (r *scanRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	for i, v := range strings.Split(r.rows[0], ",") {
+		if v != "NULL" {
+			dest[i] = v
+		} else {
+			dest[i] = nil
+		}
+	}
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func init() {
+	sql.Register("optionalscan", scanDriver{})
+}
+
+type scanAudit struct {
+	CreatedAt Optional[time.Duration] `db:"created_after"`
+}
+
+type scanUser struct {
+	ID       int64
+	Name     string           `db:"name"`
+	Nickname Optional[string] `db:"nick"`
+	Age      Optional[int]
+	Manager  *string `db:"manager"`
+	Ignored  string  `db:"-"`
+	scanAudit
+}
+
+func queryRows(t *testing.T, query string) *sql.Rows {
+	t.Helper()
+	db, err := sql.Open("optionalscan", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	rows, err := db.Query(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { rows.Close() })
+	return rows
+}
+
+func TestScanStruct(t *testing.T) {
+	rows := queryRows(t, "id,name,nick,AGE,manager,created_after\n1,Ada,NULL,36,NULL,5\n2,Bob,bobby,NULL,Ada,NULL")
+	var users []scanUser
+	for rows.Next() {
+		var u scanUser
+		if err := ScanStruct(rows, &u); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		users = append(users, u)
+	}
+	if len(users) != 2 {
+		t.Fatalf("Expected 2 users, but got %d", len(users))
+	}
+	if u := users[0]; u.ID != 1 || u.Name != "Ada" || u.Nickname.IsPresent() || u.Age.OrZero() != 36 || u.Manager != nil || u.CreatedAt.OrZero() != 5 {
+		t.Errorf("Expected Ada without nickname or manager, but got %+v", u)
+	}
+	if u := users[1]; u.Nickname.OrZero() != "bobby" || u.Age.IsPresent() || u.Manager == nil || *u.Manager != "Ada" {
+		t.Errorf("Expected bobby managed by Ada, but got %+v", u)
+	}
+}
+
+func TestScanStructErrors(t *testing.T) {
+	for name, query := range map[string]string{
+		"NULL into a plain field": "id,name\n1,NULL",
+		"unknown column":          "id,email\n1,a@example.com",
+		"ignored column":          "id,ignored\n1,x",
+	} {
+		rows := queryRows(t, query)
+		rows.Next()
+		var u scanUser
+		if err := ScanStruct(rows, &u); err == nil || !strings.HasPrefix(err.Error(), "optional: ") {
+			t.Errorf("Expected an error for %s, but got %v", name, err)
+		}
+	}
+
+	rows := queryRows(t, "x\n1")
+	rows.Next()
+	var n int
+	if err := ScanStruct(rows, &n); err == nil {
+		t.Errorf("Expected an error for a non-struct destination, but got nil")
+	}
+}
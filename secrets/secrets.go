@@ -0,0 +1,105 @@
+// Package secrets looks up credentials from pluggable sources, expressing a
+// missing secret as an empty Optional rather than an empty string:
+//
+//	src := secrets.Chain(
+//		secrets.Env("APP_"),
+//		secrets.Dir("/run/secrets"),
+//		secrets.Exec("pass", "show"),
+//	)
+//	password := src.Lookup("db-password").OrElseThrow(errors.New("no database password"))
+//
+// A secret that is empty, or that a source fails to read, counts as
+// missing.
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hermann-craft/optional"
+)
+
+// SecretSource looks up secrets by name.
+type SecretSource interface {
+	Lookup(name string) optional.Optional[string]
+}
+
+// SourceFunc adapts a function to a SecretSource.
+type SourceFunc func(name string) optional.Optional[string]
+
+// Lookup calls f.
+func (f SourceFunc) Lookup(name string) optional.Optional[string] {
+	return f(name)
+}
+
+// Chain returns a SecretSource returning the secret from the first of
+// sources that has it.
+func Chain(sources ...SecretSource) SecretSource {
+	return SourceFunc(func(name string) optional.Optional[string] {
+		for _, src := range sources {
+			if s := src.Lookup(name); s.IsPresent() {
+				return s
+			}
+		}
+		return optional.Empty[string]()
+	})
+}
+
+// Env returns a SecretSource reading the environment variable named by
+// prefix followed by the secret name in upper case, with dashes and dots
+// replaced by underscores, so db-password with prefix APP_ is read from
+// APP_DB_PASSWORD.
+func Env(prefix string) SecretSource {
+	replacer := strings.NewReplacer("-", "_", ".", "_")
+	return SourceFunc(func(name string) optional.Optional[string] {
+		return nonEmpty(os.Getenv(prefix + replacer.Replace(strings.ToUpper(name))))
+	})
+}
+
+// Dir returns a SecretSource reading the file named after the secret in
+// dir, as Docker and Kubernetes mount secrets. Trailing newlines are
+// removed.
+func Dir(dir string) SecretSource {
+	return SourceFunc(func(name string) optional.Optional[string] {
+		if !filepath.IsLocal(name) {
+			return optional.Empty[string]()
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return optional.Empty[string]()
+		}
+		return nonEmpty(strings.TrimRight(string(data), "\r\n"))
+	})
+}
+
+// Exec returns a SecretSource running command with args followed by the
+// secret name and reading the secret from its standard output, as with
+// password managers such as pass. Trailing newlines are removed, and a
+// command failing counts as the secret missing.
+func Exec(command string, args ...string) SecretSource {
+	return ExecContext(context.Background(), command, args...)
+}
+
+// ExecContext is like Exec, killing the command if ctx is done.
+func ExecContext(ctx context.Context, command string, args ...string) SecretSource {
+	return SourceFunc(func(name string) optional.Optional[string] {
+		cmd := exec.CommandContext(ctx, command, append(args[:len(args):len(args)], name)...)
+		var stdout bytes.Buffer
+		cmd.Stdout = &stdout
+		if err := cmd.Run(); err != nil {
+			return optional.Empty[string]()
+		}
+		return nonEmpty(strings.TrimRight(stdout.String(), "\r\n"))
+	})
+}
+
+func nonEmpty(s string) optional.Optional[string] {
+	if s == "" {
+		return optional.Empty[string]()
+	}
+	return optional.Of(s)
+}
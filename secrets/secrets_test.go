@@ -0,0 +1,77 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hermann-craft/optional"
+)
+
+func TestEnv(t *testing.T) {
+	t.Setenv("APP_DB_PASSWORD", "hunter2")
+	t.Setenv("APP_EMPTY", "")
+	src := Env("APP_")
+	if got := src.Lookup("db-password"); got.OrElse("") != "hunter2" {
+		t.Errorf("Expected Optional[hunter2], but got %v", got)
+	}
+	for _, name := range []string{"empty", "missing"} {
+		if got := src.Lookup(name); got.IsPresent() {
+			t.Errorf("Expected %s to be missing, but got %v", name, got)
+		}
+	}
+}
+
+func TestDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "token"), []byte("abc\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	src := Dir(dir)
+	if got := src.Lookup("token"); got.OrElse("") != "abc" {
+		t.Errorf("Expected Optional[abc], but got %v", got)
+	}
+	for _, name := range []string{"missing", "../token"} {
+		if got := src.Lookup(name); got.IsPresent() {
+			t.Errorf("Expected %s to be missing, but got %v", name, got)
+		}
+	}
+}
+
+func TestExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	src := Exec("sh", "-c", `test "$1" = api-key && echo s3cret`, "sh")
+	if got := src.Lookup("api-key"); got.OrElse("") != "s3cret" {
+		t.Errorf("Expected Optional[s3cret], but got %v", got)
+	}
+	if got := src.Lookup("other"); got.IsPresent() {
+		t.Errorf("Expected a failing command to mean missing, but got %v", got)
+	}
+}
+
+func TestChain(t *testing.T) {
+	calls := 0
+	first := SourceFunc(func(name string) optional.Optional[string] {
+		calls++
+		if name == "a" {
+			return optional.Of("first")
+		}
+		return optional.Empty[string]()
+	})
+	second := SourceFunc(func(name string) optional.Optional[string] {
+		return optional.Of("second")
+	})
+	src := Chain(first, second)
+	if got := src.Lookup("a"); got.OrElse("") != "first" {
+		t.Errorf("Expected Optional[first], but got %v", got)
+	}
+	if got := src.Lookup("b"); got.OrElse("") != "second" || calls != 2 {
+		t.Errorf("Expected Optional[second], but got %v", got)
+	}
+	if got := Chain().Lookup("a"); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
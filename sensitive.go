@@ -0,0 +1,93 @@
+package optional
+
+import (
+	"encoding/json"
+	"log/slog"
+)
+
+// redacted replaces a present sensitive value in every textual output.
+const redacted = "[REDACTED]"
+
+// Sensitive holds an optional secret, such as a password or an API token.
+// Its String, GoString, LogValue, MarshalText and MarshalJSON methods never
+// reveal the value, only whether there is one, so it cannot leak into logs
+// or responses by accident; Get and Optional still return the real value.
+// UnmarshalJSON decodes the real value, so Sensitive fields can be loaded
+// from configuration files.
+type Sensitive[T any] struct {
+	value Optional[T]
+}
+
+// Secret creates a Sensitive holding the given value.
+func Secret[T any](value T) Sensitive[T] {
+	return Sensitive[T]{value: Of(value)}
+}
+
+// SensitiveOf wraps an Optional in a Sensitive.
+func SensitiveOf[T any](o Optional[T]) Sensitive[T] {
+	return Sensitive[T]{value: o}
+}
+
+// Optional returns the wrapped Optional, revealing the value.
+func (s Sensitive[T]) Optional() Optional[T] {
+	return s.value
+}
+
+// IsPresent returns true if a value is present.
+func (s Sensitive[T]) IsPresent() bool {
+	return s.value.IsPresent()
+}
+
+// Get returns the value if present, otherwise it panics.
+func (s Sensitive[T]) Get() T {
+	return s.value.Get()
+}
+
+// String returns "Optional[[REDACTED]]", or "Optional.empty" if no value is
+// present.
+func (s Sensitive[T]) String() string {
+	if s.value.IsEmpty() {
+		return s.value.String()
+	}
+	return "Optional[" + redacted + "]"
+}
+
+// GoString is like String, so that the %#v verb does not reveal the value.
+func (s Sensitive[T]) GoString() string {
+	return s.String()
+}
+
+// LogValue implements slog.LogValuer, logging "[REDACTED]" or nothing.
+func (s Sensitive[T]) LogValue() slog.Value {
+	if s.value.IsEmpty() {
+		return slog.Value{}
+	}
+	return slog.StringValue(redacted)
+}
+
+// MarshalText encodes "[REDACTED]", or empty text if no value is present.
+func (s Sensitive[T]) MarshalText() ([]byte, error) {
+	if s.value.IsEmpty() {
+		return []byte{}, nil
+	}
+	return []byte(redacted), nil
+}
+
+// MarshalJSON encodes "[REDACTED]", or null if no value is present.
+func (s Sensitive[T]) MarshalJSON() ([]byte, error) {
+	if s.value.IsEmpty() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(redacted)
+}
+
+// UnmarshalJSON decodes the real value, leaving the Sensitive empty for null.
+func (s *Sensitive[T]) UnmarshalJSON(data []byte) error {
+	return s.value.UnmarshalJSON(data)
+}
+
+// IsZero returns true if no value is present, so that fields tagged omitzero
+// are omitted from JSON output when empty.
+func (s Sensitive[T]) IsZero() bool {
+	return s.value.IsEmpty()
+}
@@ -0,0 +1,68 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSensitiveRedacts(t *testing.T) {
+	s := Secret("hunter2")
+	if s.Get() != "hunter2" || s.Optional().OrElse("") != "hunter2" {
+		t.Errorf("Expected Get to return the real value, but got %q", s.Get())
+	}
+	for verb, got := range map[string]string{
+		"%v":  fmt.Sprintf("%v", s),
+		"%s":  fmt.Sprintf("%s", s),
+		"%+v": fmt.Sprintf("%+v", s),
+		"%#v": fmt.Sprintf("%#v", s),
+	} {
+		if strings.Contains(got, "hunter2") {
+			t.Errorf("Expected %s to redact the value, but got %s", verb, got)
+		}
+	}
+	if got := s.String(); got != "Optional[[REDACTED]]" {
+		t.Errorf("Expected Optional[[REDACTED]], but got %s", got)
+	}
+	if got := SensitiveOf(Empty[string]()).String(); got != "Optional.empty" {
+		t.Errorf("Expected Optional.empty, but got %s", got)
+	}
+}
+
+func TestSensitiveLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("login", "password", Secret("hunter2"))
+	if out := buf.String(); strings.Contains(out, "hunter2") || !strings.Contains(out, "password=[REDACTED]") {
+		t.Errorf("Expected the password to be redacted, but got %s", out)
+	}
+}
+
+func TestSensitiveJSON(t *testing.T) {
+	type config struct {
+		Token Sensitive[string] `json:"token"`
+		Empty Sensitive[string] `json:"empty"`
+	}
+	data, err := json.Marshal(config{Token: Secret("abc")})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if got := string(data); got != `{"token":"[REDACTED]","empty":null}` {
+		t.Errorf("Expected redacted JSON, but got %s", got)
+	}
+	text, _ := Secret("abc").MarshalText()
+	if string(text) != "[REDACTED]" {
+		t.Errorf("Expected redacted text, but got %s", text)
+	}
+
+	var c config
+	if err := json.Unmarshal([]byte(`{"token":"abc","empty":null}`), &c); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if c.Token.Get() != "abc" || c.Empty.IsPresent() {
+		t.Errorf("Expected the real value to be decoded, but got %q and %v", c.Token.Get(), c.Empty.IsPresent())
+	}
+}
@@ -0,0 +1,120 @@
+package optional
+
+import (
+	"iter"
+	"maps"
+)
+
+// Set is an unordered collection of distinct values whose lookups return
+// Optionals. The zero value is an empty set ready to use.
+type Set[T comparable] struct {
+	m map[T]struct{}
+}
+
+// NewSet returns a set holding the given values.
+func NewSet[T comparable](values ...T) *Set[T] {
+	s := &Set[T]{m: make(map[T]struct{}, len(values))}
+	for _, v := range values {
+		s.m[v] = struct{}{}
+	}
+	return s
+}
+
+// Add adds v to the set and reports whether it was not already there.
+func (s *Set[T]) Add(v T) bool {
+	if s.Contains(v) {
+		return false
+	}
+	if s.m == nil {
+		s.m = map[T]struct{}{}
+	}
+	s.m[v] = struct{}{}
+	return true
+}
+
+// Remove removes v from the set and reports whether it was there.
+func (s *Set[T]) Remove(v T) bool {
+	if !s.Contains(v) {
+		return false
+	}
+	delete(s.m, v)
+	return true
+}
+
+// Contains reports whether v is in the set.
+func (s *Set[T]) Contains(v T) bool {
+	_, ok := s.m[v]
+	return ok
+}
+
+// Len returns the number of values in the set.
+func (s *Set[T]) Len() int {
+	return len(s.m)
+}
+
+// Any returns an arbitrary value of the set, or an empty Optional if the set
+// is empty.
+func (s *Set[T]) Any() Optional[T] {
+	for v := range s.m {
+		return Optional[T]{value: &v}
+	}
+	return Empty[T]()
+}
+
+// Pop removes and returns an arbitrary value of the set, or returns an empty
+// Optional if the set is empty.
+func (s *Set[T]) Pop() Optional[T] {
+	o := s.Any()
+	o.IfPresent(func(v T) { delete(s.m, v) })
+	return o
+}
+
+// Find returns a value of the set matching pred, or an empty Optional if
+// there is none. If several values match, which one is returned is
+// unspecified.
+func (s *Set[T]) Find(pred func(T) bool) Optional[T] {
+	for v := range s.m {
+		if pred(v) {
+			return Optional[T]{value: &v}
+		}
+	}
+	return Empty[T]()
+}
+
+// All returns an iterator over the values of the set, in no particular
+// order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s.m)
+}
+
+// Union returns a new set holding the values of s and other.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	u := &Set[T]{m: maps.Clone(s.m)}
+	for v := range other.m {
+		u.Add(v)
+	}
+	return u
+}
+
+// Intersection returns a new set holding the values in both s and other.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	i := &Set[T]{}
+	for v := range s.m {
+		if other.Contains(v) {
+			i.Add(v)
+		}
+	}
+	return i
+}
+
+// Difference returns a new set holding the values of s that are not in
+// other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	d := &Set[T]{}
+	for v := range s.m {
+		if !other.Contains(v) {
+			d.Add(v)
+		}
+	}
+	return d
+}
@@ -0,0 +1,83 @@
+package optional
+
+import (
+	"slices"
+	"testing"
+)
+
+func sorted(s *Set[int]) []int {
+	return slices.Sorted(s.All())
+}
+
+func TestSetAddRemove(t *testing.T) {
+	var s Set[int]
+	if !s.Add(1) || s.Add(1) || !s.Add(2) {
+		t.Errorf("Expected Add to report new values only")
+	}
+	if s.Len() != 2 || !s.Contains(1) || s.Contains(3) {
+		t.Errorf("Expected [1 2], but got %v", sorted(&s))
+	}
+	if !s.Remove(1) || s.Remove(1) {
+		t.Errorf("Expected Remove to report present values only")
+	}
+	if got := sorted(&s); !slices.Equal(got, []int{2}) {
+		t.Errorf("Expected [2], but got %v", got)
+	}
+}
+
+func TestSetPop(t *testing.T) {
+	s := NewSet(1, 2)
+	seen := map[int]bool{}
+	for range 2 {
+		o := s.Pop()
+		if o.IsEmpty() {
+			t.Fatalf("Expected a value, but got an empty Optional")
+		}
+		seen[o.Get()] = true
+	}
+	if !seen[1] || !seen[2] || s.Len() != 0 {
+		t.Errorf("Expected to pop 1 and 2, but got %v", seen)
+	}
+	if o := s.Pop(); o.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", o)
+	}
+	if o := new(Set[int]).Any(); o.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", o)
+	}
+}
+
+func TestSetFind(t *testing.T) {
+	s := NewSet(1, 2, 3)
+	if o := s.Find(func(v int) bool { return v%2 == 0 }); o.OrElse(0) != 2 {
+		t.Errorf("Expected Optional[2], but got %v", o)
+	}
+	if o := s.Find(func(v int) bool { return v > 3 }); o.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", o)
+	}
+}
+
+func TestSetNilElement(t *testing.T) {
+	s := NewSet[*int](nil)
+	if o := s.Find(func(p *int) bool { return p == nil }); !o.IsPresent() || o.Get() != nil {
+		t.Errorf("Expected a present nil, but got %v", o)
+	}
+	if o := s.Pop(); !o.IsPresent() || o.Get() != nil || s.Len() != 0 {
+		t.Errorf("Expected to pop a present nil, but got %v", o)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a, b := NewSet(1, 2, 3), NewSet(2, 3, 4)
+	if got := sorted(a.Union(b)); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected union [1 2 3 4], but got %v", got)
+	}
+	if got := sorted(a.Intersection(b)); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("Expected intersection [2 3], but got %v", got)
+	}
+	if got := sorted(a.Difference(b)); !slices.Equal(got, []int{1}) {
+		t.Errorf("Expected difference [1], but got %v", got)
+	}
+	if got := sorted(a); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected the operands to be unchanged, but got %v", got)
+	}
+}
@@ -0,0 +1,104 @@
+// Package slogopt turns structs with Optional fields into log/slog
+// attributes, leaving out the empty ones, so request and entity logging
+// stays compact without an if statement per field:
+//
+//	logger.Info("user updated", slogopt.Attrs(patch)...)
+//	logger.Info("request", slogopt.Group("query", q))
+//
+// Attribute keys come from the slog struct tag, then the json tag, then the
+// field name; fields tagged "-" are skipped.
+package slogopt
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hermann-craft/optional/reflectopt"
+)
+
+var (
+	logValuerType = reflect.TypeFor[slog.LogValuer]()
+	timeType      = reflect.TypeFor[time.Time]()
+)
+
+// Attrs returns an attribute for every exported field of the struct v, or
+// of the struct v points to, except empty Optionals, nil pointers and
+// values whose LogValue is empty. Values implementing slog.LogValuer, such
+// as optional.Sensitive, are logged as themselves, present Optionals as
+// their value, and nested structs as groups. It returns nil if v is not a struct.
+func Attrs(v any) []slog.Attr {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	return attrs(rv)
+}
+
+// Group returns Attrs(v) as a group attribute named key.
+func Group(key string, v any) slog.Attr {
+	return slog.Attr{Key: key, Value: slog.GroupValue(Attrs(v)...)}
+}
+
+func attrs(v reflect.Value) []slog.Attr {
+	t := v.Type()
+	var out []slog.Attr
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := attrName(f)
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if a, ok := attr(name, v.Field(i)); ok {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// attr returns the attribute for the field value v, and false if v is an
+// empty Optional or a nil pointer.
+func attr(name string, v reflect.Value) (slog.Attr, bool) {
+	if v.Type().Implements(logValuerType) && v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+		if v.Interface().(slog.LogValuer).LogValue().Resolve().Any() == nil {
+			return slog.Attr{}, false
+		}
+		return slog.Any(name, v.Interface()), true
+	}
+	if reflectopt.IsOptionalType(v.Type()) {
+		inner, ok := reflectopt.ValueOf(v)
+		if !ok {
+			return slog.Attr{}, false
+		}
+		v = inner
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return slog.Attr{}, false
+		}
+		if v.Type().Implements(logValuerType) {
+			break
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct && v.Type() != timeType && !v.Type().Implements(logValuerType) {
+		return slog.Attr{Key: name, Value: slog.GroupValue(attrs(v)...)}, true
+	}
+	return slog.Any(name, v.Interface()), true
+}
+
+// attrName returns the attribute key of f, or "-" if f is skipped.
+func attrName(f reflect.StructField) string {
+	for _, key := range []string{"slog", "json"} {
+		if tag, ok := f.Tag.Lookup(key); ok {
+			if name, _, _ := strings.Cut(tag, ","); name != "" {
+				return name
+			}
+		}
+	}
+	return f.Name
+}
@@ -0,0 +1,75 @@
+package slogopt
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hermann-craft/optional"
+)
+
+type address struct {
+	City optional.Optional[string] `json:"city"`
+	Zip  optional.Optional[string] `json:"zip"`
+}
+
+type userPatch struct {
+	ID       int                          `json:"id"`
+	Name     optional.Optional[string]    `json:"name"`
+	Email    optional.Optional[string]    `slog:"email" json:"mail"`
+	Age      optional.Optional[int]       `json:"age"`
+	Since    optional.Optional[time.Time] `json:"since"`
+	Password optional.Sensitive[string]   `json:"password"`
+	Token    optional.Sensitive[string]   `json:"token"`
+	Address  address                      `json:"address"`
+	Manager  *address                     `json:"manager"`
+	Internal string                       `json:"-"`
+	secret   string
+}
+
+func logged(attrs ...slog.Attr) string {
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})).LogAttrs(context.Background(), slog.LevelInfo, "", attrs...)
+	return strings.TrimSpace(buf.String())
+}
+
+func TestAttrs(t *testing.T) {
+	p := userPatch{
+		ID:       1,
+		Name:     optional.Of("Ada"),
+		Since:    optional.Of(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+		Password: optional.Secret("hunter2"),
+		Address:  address{City: optional.Of("Paris")},
+		Internal: "x",
+		secret:   "y",
+	}
+	want := "id=1 name=Ada since=2024-01-02T00:00:00.000Z password=[REDACTED] address.city=Paris"
+	if got := logged(Attrs(&p)...); got != want {
+		t.Errorf("Expected %q, but got %q", want, got)
+	}
+	p.Email = optional.Of("ada@example.com")
+	p.Manager = &address{Zip: optional.Of("75001")}
+	if got := logged(Attrs(p)...); !strings.Contains(got, "email=ada@example.com") || !strings.Contains(got, "manager.zip=75001") {
+		t.Errorf("Expected the email and manager attributes, but got %q", got)
+	}
+	if Attrs(42) != nil {
+		t.Errorf("Expected nil for a non-struct, but got attributes")
+	}
+}
+
+func TestGroup(t *testing.T) {
+	want := "query.city=Lyon"
+	if got := logged(Group("query", address{City: optional.Of("Lyon")})); got != want {
+		t.Errorf("Expected %q, but got %q", want, got)
+	}
+}
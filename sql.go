@@ -0,0 +1,31 @@
+package optional
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// Scan implements sql.Scanner, leaving o empty for NULL. Values are
+// converted as for sql.Null, so T may itself implement sql.Scanner.
+func (o *Optional[T]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	if !n.Valid {
+		*o = Empty[T]()
+		return nil
+	}
+	*o = Optional[T]{value: &n.V}
+	return nil
+}
+
+// Value implements driver.Valuer, returning NULL when o is empty. The value
+// is converted with driver.DefaultParameterConverter, which uses its Value
+// method if T implements driver.Valuer.
+func (o Optional[T]) Value() (driver.Value, error) {
+	if o.value == nil {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(*o.value)
+}
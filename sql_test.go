@@ -0,0 +1,52 @@
+package optional
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+type celsius float64
+
+func (c celsius) Value() (driver.Value, error) {
+	return float64(c) * 10, nil
+}
+
+func TestScan(t *testing.T) {
+	var s Optional[string]
+	if err := s.Scan([]byte("hello")); err != nil || s.OrElse("") != "hello" {
+		t.Errorf("Expected Optional[hello], but got %v (%v)", s, err)
+	}
+	if err := s.Scan(nil); err != nil || s.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v (%v)", s, err)
+	}
+
+	var n Optional[int]
+	if err := n.Scan(int64(42)); err != nil || n.OrElse(0) != 42 {
+		t.Errorf("Expected Optional[42], but got %v (%v)", n, err)
+	}
+	if err := n.Scan("x"); err == nil {
+		t.Errorf("Expected an error, but got %v", n)
+	}
+
+	now := time.Now()
+	var ts Optional[time.Time]
+	if err := ts.Scan(now); err != nil || !ts.Get().Equal(now) {
+		t.Errorf("Expected Optional[%v], but got %v (%v)", now, ts, err)
+	}
+}
+
+func TestValue(t *testing.T) {
+	if v, err := Empty[int]().Value(); err != nil || v != nil {
+		t.Errorf("Expected nil, but got %v (%v)", v, err)
+	}
+	if v, err := Of(int32(7)).Value(); err != nil || v != int64(7) {
+		t.Errorf("Expected int64(7), but got %#v (%v)", v, err)
+	}
+	if v, err := Of(celsius(2)).Value(); err != nil || v != 20.0 {
+		t.Errorf("Expected 20, but got %#v (%v)", v, err)
+	}
+	if _, err := Of(struct{}{}).Value(); err == nil {
+		t.Error("Expected an error for an unsupported type, but got nil")
+	}
+}
@@ -0,0 +1,52 @@
+package optional
+
+import (
+	"sync"
+	"time"
+)
+
+// Debounce wraps an expensive supplier so that it runs at most once per
+// window: calls within window of the last computation return its result
+// instead of calling supplier again. Empty results are cached like present
+// ones. The returned function is safe for concurrent use; concurrent callers
+// that find the result stale wait for a single recomputation.
+func Debounce[T any](window time.Duration, supplier func() Optional[T]) func() Optional[T] {
+	var (
+		mu       sync.Mutex
+		last     Optional[T]
+		computed time.Time
+	)
+	return func() Optional[T] {
+		mu.Lock()
+		defer mu.Unlock()
+		if computed.IsZero() || time.Since(computed) >= window {
+			last = supplier()
+			computed = time.Now()
+		}
+		return last
+	}
+}
+
+// Limiter decides whether an event may happen now. *rate.Limiter from
+// golang.org/x/time/rate implements it.
+type Limiter interface {
+	Allow() bool
+}
+
+// RateLimit wraps a supplier so that it runs only when limiter allows it and
+// returns an empty Optional otherwise, for best-effort lookups that should
+// degrade gracefully under load. onThrottle, if not nil, is called for every
+// throttled call, for example to count them:
+//
+//	enrich := optional.RateLimit(rate.NewLimiter(10, 1), lookupGeo, throttled.Inc)
+func RateLimit[T any](limiter Limiter, supplier func() Optional[T], onThrottle func()) func() Optional[T] {
+	return func() Optional[T] {
+		if !limiter.Allow() {
+			if onThrottle != nil {
+				onThrottle()
+			}
+			return Empty[T]()
+		}
+		return supplier()
+	}
+}
@@ -0,0 +1,101 @@
+package optional
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDebounce(t *testing.T) {
+	calls := 0
+	get := Debounce(50*time.Millisecond, func() Optional[int] {
+		calls++
+		return Of(calls)
+	})
+
+	if got := get(); got.OrElse(0) != 1 {
+		t.Errorf("Expected Optional[1], but got %v", got)
+	}
+	if got := get(); got.OrElse(0) != 1 {
+		t.Errorf("Expected the cached Optional[1], but got %v", got)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if got := get(); got.OrElse(0) != 2 {
+		t.Errorf("Expected Optional[2] after the window, but got %v", got)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, but got %d", calls)
+	}
+}
+
+func TestDebounceCachesEmpty(t *testing.T) {
+	calls := 0
+	get := Debounce(time.Minute, func() Optional[string] {
+		calls++
+		return Empty[string]()
+	})
+	get()
+	if got := get(); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, but got %d", calls)
+	}
+}
+
+func TestDebounceConcurrent(t *testing.T) {
+	calls := 0
+	get := Debounce(time.Minute, func() Optional[int] {
+		calls++
+		return Of(42)
+	})
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := get(); got.OrElse(0) != 42 {
+				t.Errorf("Expected Optional[42], but got %v", got)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("Expected 1 call, but got %d", calls)
+	}
+}
+
+// budget allows a fixed number of events.
+type budget int
+
+func (b *budget) Allow() bool {
+	if *b == 0 {
+		return false
+	}
+	*b--
+	return true
+}
+
+func TestRateLimit(t *testing.T) {
+	limiter := budget(2)
+	calls, throttled := 0, 0
+	get := RateLimit(&limiter, func() Optional[string] {
+		calls++
+		return Of("geo")
+	}, func() { throttled++ })
+
+	for i := range 3 {
+		got := get()
+		if want := i < 2; got.IsPresent() != want {
+			t.Errorf("Expected call %d to be present: %v, but got %v", i, want, got)
+		}
+	}
+	if calls != 2 || throttled != 1 {
+		t.Errorf("Expected 2 calls and 1 throttled, but got %d and %d", calls, throttled)
+	}
+
+	limiter = 0
+	if got := RateLimit(&limiter, func() Optional[string] { return Of("geo") }, nil)(); got.IsPresent() {
+		t.Errorf("Expected an empty Optional without a hook, but got %v", got)
+	}
+}
@@ -0,0 +1,130 @@
+package optional
+
+import "reflect"
+
+// FlattenForTemplate converts v into plain values that template engines can
+// render without knowing about Optionals. Optionals, Nullables and Tracked
+// fields are replaced by their value, or by nil when empty; nil pointers
+// become nil and other pointers are followed. Structs become maps keyed by
+// field name, with the fields of embedded structs promoted, while structs
+// without exported fields, such as time.Time, are kept as they are. Slices
+// and arrays become []any and maps map[K]any, with their elements
+// flattened.
+//
+//	tmpl.Execute(w, optional.FlattenForTemplate(user))
+//
+// With text/template and html/template, {{with .Nickname}} then skips an
+// empty Optional and {{.Nickname}} prints its value rather than
+// Optional[...].
+func FlattenForTemplate(v any) any {
+	return FlattenForTemplateWith(v, nil)
+}
+
+// FlattenForTemplateWith is like FlattenForTemplate but replaces empty
+// values with placeholder, such as "n/a", instead of nil.
+func FlattenForTemplateWith(v, placeholder any) any {
+	return flatten(reflect.ValueOf(v), placeholder)
+}
+
+func flatten(v reflect.Value, placeholder any) any {
+	if !v.IsValid() {
+		return placeholder
+	}
+	t := v.Type()
+	switch {
+	case isNullableType(t):
+		_, inner, present := v.Interface().(reflectedNullable).nullableValue()
+		return flattenPresent(inner, present, placeholder)
+	case isOptionalType(t):
+		inner, present := optionalValue(v)
+		return flattenPresent(inner, present, placeholder)
+	case t.Implements(trackedFieldType):
+		_, inner, present := v.Interface().(trackedField).trackedValue()
+		return flattenPresent(inner, present, placeholder)
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return placeholder
+		}
+		return flatten(v.Elem(), placeholder)
+	case reflect.Struct:
+		if !hasExportedFields(t) {
+			return v.Interface()
+		}
+		m := map[string]any{}
+		flattenStruct(v, m, placeholder, false)
+		return m
+	case reflect.Slice:
+		if v.IsNil() {
+			return placeholder
+		}
+		fallthrough
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		s := make([]any, v.Len())
+		for i := range s {
+			s[i] = flatten(v.Index(i), placeholder)
+		}
+		return s
+	case reflect.Map:
+		if v.IsNil() {
+			return placeholder
+		}
+		m := reflect.MakeMapWithSize(reflect.MapOf(t.Key(), reflect.TypeFor[any]()), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			elem := reflect.ValueOf(flatten(iter.Value(), placeholder))
+			if !elem.IsValid() {
+				elem = reflect.Zero(m.Type().Elem())
+			}
+			m.SetMapIndex(iter.Key(), elem)
+		}
+		return m.Interface()
+	}
+	return v.Interface()
+}
+
+func flattenPresent(v reflect.Value, present bool, placeholder any) any {
+	if !present {
+		return placeholder
+	}
+	return flatten(v, placeholder)
+}
+
+// flattenStruct stores the flattened exported fields of the struct v in m,
+// promoting the fields of exported embedded structs that are not Optionals.
+// Promoted fields do not replace fields already in m.
+func flattenStruct(v reflect.Value, m map[string]any, placeholder any, promoted bool) {
+	t := v.Type()
+	var embedded []reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		if f.Anonymous && !isOptionalType(f.Type) && !isNullableType(f.Type) && !f.Type.Implements(trackedFieldType) {
+			if ev := indirect(fv); ev.Kind() == reflect.Struct && hasExportedFields(ev.Type()) {
+				embedded = append(embedded, ev)
+				continue
+			}
+		}
+		if _, ok := m[f.Name]; !ok || !promoted {
+			m[f.Name] = flatten(fv, placeholder)
+		}
+	}
+	for _, ev := range embedded {
+		flattenStruct(ev, m, placeholder, true)
+	}
+}
+
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package optional
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+)
+
+type templateAudit struct {
+	Created time.Time
+	Name    string
+}
+
+type templateUser struct {
+	templateAudit
+	Audit    templateAudit
+	Name     string
+	Nickname Optional[string]
+	Manager  *templateUser
+	Tags     Optional[[]string]
+	Scores   map[string]Optional[int]
+	Bio      Nullable[string]
+	secret   string
+}
+
+type TemplateBase struct {
+	ID   int
+	Name string
+}
+
+type templateItem struct {
+	TemplateBase
+	Name  string
+	Price Optional[float64]
+}
+
+func TestFlattenForTemplate(t *testing.T) {
+	created := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	u := templateUser{
+		Audit:    templateAudit{Created: created, Name: "import"},
+		Name:     "Ada",
+		Nickname: Empty[string](),
+		Tags:     Of([]string{"admin"}),
+		Scores:   map[string]Optional[int]{"go": Of(9), "rust": Empty[int]()},
+		Bio:      NullableOf("Mathematician"),
+		secret:   "x",
+	}
+	want := map[string]any{
+		"Audit":    map[string]any{"Created": created, "Name": "import"},
+		"Name":     "Ada",
+		"Nickname": nil,
+		"Manager":  nil,
+		"Tags":     []any{"admin"},
+		"Scores":   map[string]any{"go": 9, "rust": nil},
+		"Bio":      "Mathematician",
+	}
+	if got := FlattenForTemplate(u); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, but got %v", want, got)
+	}
+}
+
+func TestFlattenForTemplatePromotesEmbedded(t *testing.T) {
+	item := templateItem{TemplateBase: TemplateBase{ID: 7, Name: "base"}, Name: "Pen"}
+	want := map[string]any{"ID": 7, "Name": "Pen", "Price": "n/a"}
+	if got := FlattenForTemplateWith(&item, "n/a"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, but got %v", want, got)
+	}
+}
+
+func TestFlattenForTemplateRender(t *testing.T) {
+	tmpl := template.Must(template.New("user").Parse(`{{.Name}}{{with .Nickname}} ({{.}}){{end}}`))
+	for _, tt := range []struct {
+		user templateUser
+		want string
+	}{
+		{templateUser{Name: "Ada", Nickname: Of("Countess")}, "Ada (Countess)"},
+		{templateUser{Name: "Alan"}, "Alan"},
+	} {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, FlattenForTemplate(tt.user)); err != nil {
+			t.Fatalf("Expected no error, but got %v", err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("Expected %q, but got %q", tt.want, b.String())
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package optional
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// MarshalText encodes the value as text, or as empty text when the Optional
+// is empty. Values are encoded with the formatter registered for T by
+// RegisterFormatter, if any, and otherwise with their own MarshalText
+// method or as a string, boolean, number or time.Duration. Other values
+// fall back to String, so that log/slog and other text encoders print them
+// as fmt does.
+func (o Optional[T]) MarshalText() ([]byte, error) {
+	return o.AppendText([]byte{})
+}
+
+// AppendText implements encoding.TextAppender, appending the text MarshalText
+// returns to b. Common value types are appended without intermediate
+// allocations.
+func (o Optional[T]) AppendText(b []byte) ([]byte, error) {
+	if o.value == nil {
+		return b, nil
+	}
+	if f, ok := formatters.Load(reflect.TypeFor[T]()); ok {
+		return append(b, f.(func(T) string)(*o.value)...), nil
+	}
+	switch v := any(*o.value).(type) {
+	case string:
+		return append(b, v...), nil
+	case int:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(b, v, 10), nil
+	case bool:
+		return strconv.AppendBool(b, v), nil
+	case time.Duration:
+		return append(b, v.String()...), nil
+	}
+	switch v := any(*o.value).(type) {
+	case encoding.TextAppender:
+		return v.AppendText(b)
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		return append(b, text...), err
+	}
+	v := reflect.ValueOf(*o.value)
+	switch v.Kind() {
+	case reflect.String:
+		return append(b, v.String()...), nil
+	case reflect.Bool:
+		return strconv.AppendBool(b, v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(b, v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(b, v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(b, v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+	return append(b, o.String()...), nil
+}
+
+// UnmarshalText decodes text into the Optional, leaving it empty for empty
+// text. The text is parsed like the default struct tag of FillDefaults.
+func (o *Optional[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*o = Empty[T]()
+		return nil
+	}
+	v, err := parseDefault(string(text), reflect.TypeFor[T]())
+	if err != nil {
+		return fmt.Errorf("optional: cannot parse %q: %w", text, err)
+	}
+	value := v.Interface().(T)
+	*o = Optional[T]{value: &value}
+	return nil
+}
+
+// UnmarshalParam decodes a query, path or form parameter into the Optional
+// like UnmarshalText. It implements the BindUnmarshaler interfaces of Gin
+// and Echo, so request structs with Optional fields bind directly and
+// parameters that were not sent stay empty.
+func (o *Optional[T]) UnmarshalParam(param string) error {
+	if isParamSlice(reflect.TypeFor[T]()) {
+		return o.UnmarshalParams([]string{param})
+	}
+	return o.UnmarshalText([]byte(param))
+}
+
+// UnmarshalParams decodes the values of a repeated parameter, such as
+// ?tag=a&tag=b, into the Optional. It implements the interface Echo uses to
+// bind repeated parameters. When T is a slice, each non-empty value is
+// parsed into an element and the Optional holds the slice, so a parameter
+// sent without values, as in ?tag=, gives a present empty slice while a
+// parameter that was not sent leaves the Optional empty. For other types
+// the first value is decoded like UnmarshalParam.
+func (o *Optional[T]) UnmarshalParams(params []string) error {
+	t := reflect.TypeFor[T]()
+	if !isParamSlice(t) {
+		if len(params) == 0 {
+			*o = Empty[T]()
+			return nil
+		}
+		return o.UnmarshalText([]byte(params[0]))
+	}
+	s := reflect.MakeSlice(t, 0, len(params))
+	for _, p := range params {
+		if p == "" {
+			continue
+		}
+		v, err := parseDefault(p, t.Elem())
+		if err != nil {
+			return fmt.Errorf("optional: cannot parse %q: %w", p, err)
+		}
+		s = reflect.Append(s, v)
+	}
+	value := s.Interface().(T)
+	*o = Optional[T]{value: &value}
+	return nil
+}
+
+// isParamSlice reports whether t is a slice whose elements UnmarshalParams
+// parses one parameter value at a time. Byte slices and types implementing
+// encoding.TextUnmarshaler are parsed whole.
+func isParamSlice(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 &&
+		!reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
@@ -0,0 +1,133 @@
+package optional
+
+import (
+	"bytes"
+	"encoding"
+	"log/slog"
+	"net"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMarshalText(t *testing.T) {
+	tests := []struct {
+		in   interface{ MarshalText() ([]byte, error) }
+		want string
+	}{
+		{Of("a b"), "a b"},
+		{Of(true), "true"},
+		{Of(-42), "-42"},
+		{Of(uint8(7)), "7"},
+		{Of(1.5), "1.5"},
+		{Of(90 * time.Second), "1m30s"},
+		{Of(netip.MustParseAddr("10.0.0.1")), "10.0.0.1"},
+		{Empty[int](), ""},
+	}
+	for _, tt := range tests {
+		if got, err := tt.in.MarshalText(); err != nil || string(got) != tt.want {
+			t.Errorf("Expected %q, but got %q (%v)", tt.want, got, err)
+		}
+	}
+	if got, err := Of([]int{1}).MarshalText(); err != nil || string(got) != "Optional[[1]]" {
+		t.Errorf("Expected Optional[[1]] for a type without a text form, but got %q (%v)", got, err)
+	}
+}
+
+type textToken string
+
+func TestMarshalTextSlog(t *testing.T) {
+	RegisterFormatter(func(textToken) string { return "***" })
+	defer formatters.Delete(reflect.TypeFor[textToken]())
+
+	var b bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&b, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("m", "n", Of(42), "point", Of(struct{ X, Y int }{1, 2}), "token", Of(textToken("abc")))
+	want := `level=INFO msg=m n=42 point="Optional[{1 2}]" token=***` + "\n"
+	if b.String() != want {
+		t.Errorf("Expected %q, but got %q", want, b.String())
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	var n Optional[int]
+	if err := n.UnmarshalText([]byte("42")); err != nil || n.OrElse(0) != 42 {
+		t.Errorf("Expected Optional[42], but got %v (%v)", n, err)
+	}
+	if err := n.UnmarshalText(nil); err != nil || n.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v (%v)", n, err)
+	}
+	if err := n.UnmarshalText([]byte("x")); err == nil {
+		t.Errorf("Expected an error, but got %v", n)
+	}
+
+	var addr Optional[netip.Addr]
+	if err := addr.UnmarshalParam("::1"); err != nil || addr.Get() != netip.IPv6Loopback() {
+		t.Errorf("Expected Optional[::1], but got %v (%v)", addr, err)
+	}
+	var d Optional[time.Duration]
+	if err := d.UnmarshalParam("5m"); err != nil || d.Get() != 5*time.Minute {
+		t.Errorf("Expected Optional[5m0s], but got %v (%v)", d, err)
+	}
+}
+
+func TestUnmarshalParams(t *testing.T) {
+	var ids Optional[[]int]
+	if err := ids.UnmarshalParams([]string{"1", "2"}); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if got := ids.OrZero(); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("Expected [1 2], but got %v", ids)
+	}
+	if err := ids.UnmarshalParams([]string{""}); err != nil || !ids.IsPresent() || len(ids.Get()) != 0 {
+		t.Errorf("Expected a present empty slice, but got %v, %v", ids, err)
+	}
+	if err := ids.UnmarshalParam("7"); err != nil || len(ids.OrZero()) != 1 || ids.Get()[0] != 7 {
+		t.Errorf("Expected [7], but got %v, %v", ids, err)
+	}
+	if err := ids.UnmarshalParams([]string{"x"}); err == nil {
+		t.Errorf("Expected an error for an invalid element, but got nil")
+	}
+
+	var page Optional[int]
+	if err := page.UnmarshalParams([]string{"3", "4"}); err != nil || page.OrZero() != 3 {
+		t.Errorf("Expected Optional[3], but got %v, %v", page, err)
+	}
+}
+
+func TestOptionalAppendText(t *testing.T) {
+	var _ encoding.TextAppender = Optional[int]{}
+	for _, tc := range []struct {
+		opt  encoding.TextAppender
+		want string
+	}{
+		{Of("a"), "xa"},
+		{Of(42), "x42"},
+		{Of(int8(-3)), "x-3"},
+		{Of(true), "xtrue"},
+		{Of(90 * time.Second), "x1m30s"},
+		{Of(net.IPv4(10, 0, 0, 1)), "x10.0.0.1"},
+		{Empty[string](), "x"},
+	} {
+		got, err := tc.opt.AppendText([]byte("x"))
+		if err != nil || string(got) != tc.want {
+			t.Errorf("Expected %s, but got %s, %v", tc.want, got, err)
+		}
+	}
+	if got, err := Of([]int{1}).AppendText([]byte("x")); err != nil || string(got) != "xOptional[[1]]" {
+		t.Errorf("Expected xOptional[[1]] for a slice, but got %s, %v", got, err)
+	}
+	buf := make([]byte, 0, 64)
+	o := Of(12345)
+	if allocs := testing.AllocsPerRun(100, func() { buf, _ = o.AppendText(buf[:0]) }); allocs != 0 {
+		t.Errorf("Expected no allocations, but got %v", allocs)
+	}
+}
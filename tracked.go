@@ -0,0 +1,157 @@
+package optional
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Tracked is a struct field that records whether it was modified since it
+// was loaded. Changed and Updates report the modified Tracked fields of a
+// struct, which is what ORMs call change tracking.
+type Tracked[T any] struct {
+	value   Optional[T]
+	changed bool
+}
+
+// Loaded creates an unchanged Tracked holding the given value, as read from
+// a store.
+func Loaded[T any](value T) Tracked[T] {
+	return Tracked[T]{value: Of(value)}
+}
+
+// Set replaces the value and marks the field as changed.
+func (t *Tracked[T]) Set(value T) {
+	t.value = Of(value)
+	t.changed = true
+}
+
+// Clear empties the field and marks it as changed.
+func (t *Tracked[T]) Clear() {
+	t.value = Empty[T]()
+	t.changed = true
+}
+
+// Value returns the current value.
+func (t Tracked[T]) Value() Optional[T] {
+	return t.value
+}
+
+// IsChanged returns true if Set or Clear was called since the field was
+// loaded or last reset.
+func (t Tracked[T]) IsChanged() bool {
+	return t.changed
+}
+
+// Reset marks the field as unchanged, typically after it was saved.
+func (t *Tracked[T]) Reset() {
+	t.changed = false
+}
+
+// String returns a string representation of the current value.
+func (t Tracked[T]) String() string {
+	return t.value.String()
+}
+
+// MarshalJSON encodes the current value.
+func (t Tracked[T]) MarshalJSON() ([]byte, error) {
+	return t.value.MarshalJSON()
+}
+
+// UnmarshalJSON decodes data into the field and marks it as changed.
+func (t *Tracked[T]) UnmarshalJSON(data []byte) error {
+	if err := t.value.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	t.changed = true
+	return nil
+}
+
+// trackedField is implemented by every Tracked.
+type trackedField interface {
+	trackedValue() (changed bool, value reflect.Value, present bool)
+}
+
+var trackedFieldType = reflect.TypeFor[trackedField]()
+
+func (t Tracked[T]) trackedValue() (bool, reflect.Value, bool) {
+	v, present := t.value.reflectValue()
+	return t.changed, v, present
+}
+
+// Changed returns the names of the Tracked fields of the struct v, or of the
+// struct v points to, that changed since they were loaded. Fields of
+// embedded structs are included under their own names.
+func Changed(v any) []string {
+	var names []string
+	walkTracked(reflect.ValueOf(v), func(f reflect.StructField, changed bool, _ reflect.Value, _ bool) {
+		if changed {
+			names = append(names, f.Name)
+		}
+	})
+	return names
+}
+
+// Updates returns the new value of every changed Tracked field of the struct
+// v, or of the struct v points to, keyed by the field's db tag name or, if it
+// has none, its Go name. Cleared fields map to nil.
+func Updates(v any) map[string]any {
+	updates := map[string]any{}
+	walkTracked(reflect.ValueOf(v), func(f reflect.StructField, changed bool, value reflect.Value, present bool) {
+		if !changed {
+			return
+		}
+		key := f.Name
+		if name, _, _ := strings.Cut(f.Tag.Get("db"), ","); name != "" && name != "-" {
+			key = name
+		}
+		updates[key] = nil
+		if present {
+			updates[key] = value.Interface()
+		}
+	})
+	return updates
+}
+
+// ResetTracking marks every Tracked field of the struct v points to as
+// unchanged.
+func ResetTracking(v any) {
+	resetTracked(indirect(reflect.ValueOf(v)))
+}
+
+func walkTracked(v reflect.Value, fn func(f reflect.StructField, changed bool, value reflect.Value, present bool)) {
+	v = indirect(v)
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		switch {
+		case !f.IsExported() && !f.Anonymous:
+		case f.Type.Implements(trackedFieldType):
+			changed, value, present := v.Field(i).Interface().(trackedField).trackedValue()
+			fn(f, changed, value, present)
+		case f.Anonymous:
+			walkTracked(v.Field(i), fn)
+		}
+	}
+}
+
+func resetTracked(v reflect.Value) {
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		switch {
+		case !f.IsExported() && !f.Anonymous:
+		case f.Type.Implements(trackedFieldType):
+			if v.Field(i).CanSet() {
+				v.Field(i).Addr().Interface().(interface{ Reset() }).Reset()
+			}
+		case f.Anonymous:
+			resetTracked(indirect(v.Field(i)))
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package optional
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type trackedBase struct {
+	ID Tracked[int] `db:"id"`
+}
+
+type trackedUser struct {
+	trackedBase
+	Name  Tracked[string] `db:"name"`
+	Email Tracked[string] `db:"email"`
+	Age   Tracked[int]
+	Note  string
+}
+
+func TestTracked(t *testing.T) {
+	u := trackedUser{Name: Loaded("Alice"), Email: Loaded("alice@example.com")}
+	if u.Name.IsChanged() || len(Changed(&u)) != 0 {
+		t.Errorf("Expected no changes after load, but got %v", Changed(&u))
+	}
+
+	u.Name.Set("Bob")
+	u.Email.Clear()
+	u.ID.Set(7)
+	if got := Changed(u); !reflect.DeepEqual(got, []string{"ID", "Name", "Email"}) {
+		t.Errorf("Expected [ID Name Email], but got %v", got)
+	}
+	want := map[string]any{"id": 7, "name": "Bob", "email": nil}
+	if got := Updates(&u); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, but got %v", want, got)
+	}
+	if u.Name.Value().Get() != "Bob" || u.Email.Value().IsPresent() {
+		t.Errorf("Expected Bob and an empty email, but got %v and %v", u.Name, u.Email)
+	}
+
+	ResetTracking(&u)
+	if got := Changed(&u); len(got) != 0 {
+		t.Errorf("Expected no changes after reset, but got %v", got)
+	}
+	if u.Name.Value().Get() != "Bob" {
+		t.Errorf("Expected reset to keep the value, but got %v", u.Name)
+	}
+}
+
+func TestTrackedJSON(t *testing.T) {
+	var u trackedUser
+	if err := json.Unmarshal([]byte(`{"Age":30}`), &u); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := Updates(&u); !reflect.DeepEqual(got, map[string]any{"Age": 30}) {
+		t.Errorf("Expected map[Age:30], but got %v", got)
+	}
+	data, err := json.Marshal(struct{ Name Tracked[string] }{Loaded("Alice")})
+	if err != nil || string(data) != `{"Name":"Alice"}` {
+		t.Errorf("Expected {\"Name\":\"Alice\"}, but got %s (%v)", data, err)
+	}
+}
@@ -0,0 +1,71 @@
+package optional
+
+import (
+	"strings"
+	"time"
+)
+
+// String is an Optional[string] with helpers specific to strings. It embeds
+// the Optional, so it has all of its methods and encodes the same way:
+//
+//	name := optional.String{Optional: optional.Of("  Ada ")}
+//	name.TrimmedNonEmpty() // Optional[Ada]
+type String struct {
+	Optional[string]
+}
+
+// NonEmpty returns the value if it is present and not "", otherwise an
+// empty Optional.
+func (s String) NonEmpty() Optional[string] {
+	return s.Filter(func(v string) bool { return v != "" })
+}
+
+// TrimmedNonEmpty returns the value with leading and trailing white space
+// removed if the result is not "", otherwise an empty Optional.
+func (s String) TrimmedNonEmpty() Optional[string] {
+	return String{s.Normalize(strings.TrimSpace)}.NonEmpty()
+}
+
+// Int is an Optional[int] with helpers specific to integers.
+type Int struct {
+	Optional[int]
+}
+
+// Positive returns the value if it is present and greater than zero,
+// otherwise an empty Optional.
+func (i Int) Positive() Optional[int] {
+	return i.Filter(func(v int) bool { return v > 0 })
+}
+
+// Clamp returns the value limited to the range [lo, hi], or an empty Int if
+// the value is not present.
+func (i Int) Clamp(lo, hi int) Int {
+	return Int{i.Normalize(func(v int) int { return min(max(v, lo), hi) })}
+}
+
+// Time is an Optional[time.Time] with helpers specific to times.
+type Time struct {
+	Optional[time.Time]
+}
+
+// Before reports whether the time is present and before u.
+func (t Time) Before(u time.Time) bool {
+	return t.IsPresent() && t.Get().Before(u)
+}
+
+// After reports whether the time is present and after u.
+func (t Time) After(u time.Time) bool {
+	return t.IsPresent() && t.Get().After(u)
+}
+
+// Format returns the time formatted with layout, or an empty Optional if the
+// time is not present.
+func (t Time) Format(layout string) Optional[string] {
+	return Map(t.Optional, func(v time.Time) string { return v.Format(layout) })
+}
+
+// In returns the time in the location loc, or an empty Time if the time is
+// not present.
+func (t Time) In(loc *time.Location) Time {
+	return Time{Map(t.Optional, func(v time.Time) time.Time { return v.In(loc) })}
+}
@@ -0,0 +1,81 @@
+package optional
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		in                String
+		nonEmpty, trimmed string
+	}{
+		{String{Of("  Ada ")}, "  Ada ", "Ada"},
+		{String{Of("   ")}, "   ", ""},
+		{String{Of("")}, "", ""},
+		{String{Empty[string]()}, "", ""},
+	}
+	for _, tt := range tests {
+		if got := tt.in.NonEmpty().OrElse(""); got != tt.nonEmpty {
+			t.Errorf("Expected NonEmpty of %v to be %q, but got %q", tt.in, tt.nonEmpty, got)
+		}
+		if got := tt.in.TrimmedNonEmpty(); got.OrElse("") != tt.trimmed || got.IsPresent() != (tt.trimmed != "") {
+			t.Errorf("Expected TrimmedNonEmpty of %v to be %q, but got %v", tt.in, tt.trimmed, got)
+		}
+	}
+}
+
+func TestInt(t *testing.T) {
+	if got := (Int{Of(5)}).Positive(); got.OrElse(0) != 5 {
+		t.Errorf("Expected Optional[5], but got %v", got)
+	}
+	if got := (Int{Of(0)}).Positive(); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	if got := (Int{Of(150)}).Clamp(1, 100); got.OrElse(0) != 100 {
+		t.Errorf("Expected Optional[100], but got %v", got)
+	}
+	if got := (Int{Empty[int]()}).Clamp(1, 100); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+}
+
+func TestTime(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	present, empty := Time{Of(now)}, Time{Empty[time.Time]()}
+	if !present.Before(now.Add(time.Hour)) || present.After(now.Add(time.Hour)) {
+		t.Errorf("Expected %v to be before %v", present, now.Add(time.Hour))
+	}
+	if empty.Before(now) || empty.After(now) {
+		t.Errorf("Expected an empty Time to be neither before nor after %v", now)
+	}
+	if got := present.Format(time.DateOnly); got.OrElse("") != "2024-05-01" {
+		t.Errorf("Expected Optional[2024-05-01], but got %v", got)
+	}
+	if got := empty.Format(time.DateOnly); got.IsPresent() {
+		t.Errorf("Expected an empty Optional, but got %v", got)
+	}
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	if got := present.In(loc); got.Get().Location() != loc || !got.Get().Equal(now) {
+		t.Errorf("Expected the same instant in UTC+2, but got %v", got)
+	}
+}
+
+func TestNamedTypesJSON(t *testing.T) {
+	type user struct {
+		Name String `json:"name"`
+		Age  Int    `json:"age"`
+	}
+	data, err := json.Marshal(user{Name: String{Of("Ada")}})
+	if err != nil || string(data) != `{"name":"Ada","age":null}` {
+		t.Errorf("Expected {\"name\":\"Ada\",\"age\":null}, but got %s, %v", data, err)
+	}
+	var u user
+	if err := json.Unmarshal([]byte(`{"name":null,"age":36}`), &u); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if u.Name.IsPresent() || u.Age.OrElse(0) != 36 {
+		t.Errorf("Expected an empty name and age 36, but got %v and %v", u.Name, u.Age)
+	}
+}
@@ -0,0 +1,27 @@
+// Package uuidopt parses optional identifiers with a UUID library such as
+// github.com/google/uuid. The parse function is passed in, so the package
+// does not import a library itself and works with any of them.
+package uuidopt
+
+import (
+	"strings"
+
+	"github.com/hermann-craft/optional"
+)
+
+// ParseUUID parses s with parse, such as uuid.Parse, returning an empty
+// Optional if s is blank or invalid, as for identifiers taken from query
+// parameters and headers:
+//
+//	tenant := uuidopt.ParseUUID(r.Header.Get("X-Tenant-ID"), uuid.Parse)
+func ParseUUID[U any](s string, parse func(string) (U, error)) optional.Optional[U] {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return optional.Empty[U]()
+	}
+	id, err := parse(s)
+	if err != nil {
+		return optional.Empty[U]()
+	}
+	return optional.Of(id)
+}
@@ -0,0 +1,39 @@
+package uuidopt
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// parse mirrors uuid.Parse for the hyphenated and urn:uuid: forms.
+func parse(s string) ([16]byte, error) {
+	var id [16]byte
+	s = strings.TrimPrefix(s, "urn:uuid:")
+	if len(s) != 36 {
+		return id, errors.New("invalid UUID length")
+	}
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil || len(b) != 16 {
+		return id, errors.New("invalid UUID format")
+	}
+	copy(id[:], b)
+	return id, nil
+}
+
+func TestParseUUID(t *testing.T) {
+	const s = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	id, _ := parse(s)
+	if got := ParseUUID(" "+s+" ", parse); got.OrZero() != id {
+		t.Errorf("Expected Optional[%x], but got %v", id, got)
+	}
+	if got := ParseUUID("urn:uuid:"+s, parse); got.OrZero() != id {
+		t.Errorf("Expected Optional[%x], but got %v", id, got)
+	}
+	for _, in := range []string{"", "  ", "not-a-uuid", s[:35]} {
+		if got := ParseUUID(in, parse); got.IsPresent() {
+			t.Errorf("Expected an empty Optional for %q, but got %v", in, got)
+		}
+	}
+}
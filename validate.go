@@ -0,0 +1,30 @@
+package optional
+
+import "errors"
+
+// ErrNotPresent is returned by ValidatePresent for an empty Optional. The
+// *AbsentError that Get, OrElseThrow and OptionalAny.Get panic with when
+// empty also matches it with errors.Is.
+var ErrNotPresent = errors.New("optional: no value present")
+
+// Validate runs every check against the value if present and returns their
+// failures joined with errors.Join. An empty Optional passes.
+func (o Optional[T]) Validate(checks ...func(T) error) error {
+	if o.value == nil {
+		return nil
+	}
+	errs := make([]error, 0, len(checks))
+	for _, check := range checks {
+		errs = append(errs, check(*o.value))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidatePresent is like Validate, but returns ErrNotPresent for an empty
+// Optional.
+func (o Optional[T]) ValidatePresent(checks ...func(T) error) error {
+	if o.value == nil {
+		return ErrNotPresent
+	}
+	return o.Validate(checks...)
+}
@@ -0,0 +1,54 @@
+package optional
+
+import (
+	"errors"
+	"testing"
+)
+
+var (
+	errTooShort = errors.New("too short")
+	errNoAt     = errors.New("missing @")
+)
+
+func minLen(n int) func(string) error {
+	return func(s string) error {
+		if len(s) < n {
+			return errTooShort
+		}
+		return nil
+	}
+}
+
+func hasAt(s string) error {
+	for _, r := range s {
+		if r == '@' {
+			return nil
+		}
+	}
+	return errNoAt
+}
+
+func TestValidate(t *testing.T) {
+	if err := Of("alice@example.com").Validate(minLen(5), hasAt); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+	err := Of("a").Validate(minLen(5), hasAt)
+	if !errors.Is(err, errTooShort) || !errors.Is(err, errNoAt) {
+		t.Errorf("Expected both failures, but got %v", err)
+	}
+	if err := Empty[string]().Validate(minLen(5)); err != nil {
+		t.Errorf("Expected an empty Optional to pass, but got %v", err)
+	}
+}
+
+func TestValidatePresent(t *testing.T) {
+	if err := Empty[string]().ValidatePresent(minLen(5)); !errors.Is(err, ErrNotPresent) {
+		t.Errorf("Expected ErrNotPresent, but got %v", err)
+	}
+	if err := Of("a").ValidatePresent(hasAt); !errors.Is(err, errNoAt) {
+		t.Errorf("Expected %v, but got %v", errNoAt, err)
+	}
+	if err := Of("a@b").ValidatePresent(); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+}
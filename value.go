@@ -0,0 +1,41 @@
+package optional
+
+import "time"
+
+// Scalar is the constraint of ValueOptional. It admits the basic types,
+// types defined on them such as time.Duration, and time.Time, but no
+// pointers, interfaces, slices, maps, channels or functions, which are the
+// types for which an Optional can hold a value that is itself nil.
+type Scalar interface {
+	~bool | ~string |
+		~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 | ~complex64 | ~complex128 |
+		time.Time
+}
+
+// ValueOptional is an Optional restricted at compile time to Scalar
+// types, for code that bans optionals of pointers and interfaces, whose
+// present values may be nil. It embeds the Optional, so it has all of its
+// methods and encodes the same way:
+//
+//	age := optional.ValueOf(42)       // ValueOptional[int]
+//	ref := optional.ValueOf(&user)    // does not compile
+type ValueOptional[T Scalar] struct {
+	Optional[T]
+}
+
+// ValueOf creates a ValueOptional containing value.
+func ValueOf[T Scalar](value T) ValueOptional[T] {
+	return ValueOptional[T]{Optional[T]{value: &value}}
+}
+
+// ValueEmpty creates an empty ValueOptional.
+func ValueEmpty[T Scalar]() ValueOptional[T] {
+	return ValueOptional[T]{Optional[T]{origin: captureOrigin()}}
+}
+
+// ValueFrom converts an Optional of a Scalar type into a ValueOptional.
+func ValueFrom[T Scalar](o Optional[T]) ValueOptional[T] {
+	return ValueOptional[T]{o}
+}
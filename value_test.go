@@ -0,0 +1,36 @@
+package optional
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestValueOptional(t *testing.T) {
+	if v := ValueOf(42); !v.IsPresent() || v.Get() != 42 {
+		t.Errorf("Expected 42, but got %v", v)
+	}
+	if v := ValueEmpty[time.Duration](); v.IsPresent() {
+		t.Errorf("Expected an empty ValueOptional, but got %v", v)
+	}
+	if v := ValueFrom(Of(time.Unix(0, 0))); !v.IsPresent() {
+		t.Errorf("Expected a present ValueOptional, but got %v", v)
+	}
+}
+
+func TestValueOptionalJSON(t *testing.T) {
+	var s struct {
+		Age  ValueOptional[int]    `json:"age"`
+		Name ValueOptional[string] `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(`{"age":30,"name":null}`), &s); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if s.Age.OrZero() != 30 || s.Name.IsPresent() {
+		t.Errorf("Expected age 30 and no name, but got %v and %v", s.Age, s.Name)
+	}
+	data, err := json.Marshal(s)
+	if err != nil || string(data) != `{"age":30,"name":null}` {
+		t.Errorf("Expected {\"age\":30,\"name\":null}, but got %s, %v", data, err)
+	}
+}
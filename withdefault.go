@@ -0,0 +1,42 @@
+package optional
+
+// WithDefault is an Optional carrying its own fallback value, fixed when it
+// is declared, for configuration values. Get never fails: it returns the
+// default when the Optional is empty, while IsPresent still reports whether
+// a value was set. It embeds the Optional, so it has all of its other
+// methods and decodes the same way, keeping the default:
+//
+//	cfg := Config{Port: optional.NewWithDefault(8080)}
+//	err := json.Unmarshal(data, &cfg)
+//	cfg.Port.Get()       // the configured port, or 8080
+//	cfg.Port.IsPresent() // whether the port was configured
+type WithDefault[T any] struct {
+	Optional[T]
+	def T
+}
+
+// NewWithDefault creates an empty WithDefault falling back to def.
+func NewWithDefault[T any](def T) WithDefault[T] {
+	return WithDefault[T]{Optional: Empty[T](), def: def}
+}
+
+// WithDefaultOf creates a WithDefault holding the value of o, falling back
+// to def when o is empty.
+func WithDefaultOf[T any](o Optional[T], def T) WithDefault[T] {
+	return WithDefault[T]{Optional: o, def: def}
+}
+
+// Get returns the value if present, otherwise the default.
+func (w WithDefault[T]) Get() T {
+	return w.OrElse(w.def)
+}
+
+// Default returns the default value.
+func (w WithDefault[T]) Default() T {
+	return w.def
+}
+
+// Set returns a copy of w holding value, with the same default.
+func (w WithDefault[T]) Set(value T) WithDefault[T] {
+	return WithDefault[T]{Optional: Of(value), def: w.def}
+}
@@ -0,0 +1,37 @@
+package optional
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWithDefault(t *testing.T) {
+	port := NewWithDefault(8080)
+	if port.IsPresent() || port.Get() != 8080 || port.Default() != 8080 {
+		t.Errorf("Expected an empty WithDefault returning 8080, but got %v", port.Get())
+	}
+	port = port.Set(9090)
+	if !port.IsPresent() || port.Get() != 9090 || port.Default() != 8080 {
+		t.Errorf("Expected 9090 with default 8080, but got %v and %v", port.Get(), port.Default())
+	}
+	if got := WithDefaultOf(Empty[string](), "info").Get(); got != "info" {
+		t.Errorf("Expected info, but got %s", got)
+	}
+}
+
+func TestWithDefaultJSON(t *testing.T) {
+	type config struct {
+		Port  WithDefault[int]    `json:"port"`
+		Level WithDefault[string] `json:"level"`
+	}
+	cfg := config{Port: NewWithDefault(8080), Level: NewWithDefault("info")}
+	if err := json.Unmarshal([]byte(`{"level":"debug"}`), &cfg); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if cfg.Port.IsPresent() || cfg.Port.Get() != 8080 {
+		t.Errorf("Expected the default port 8080, but got %v", cfg.Port.Get())
+	}
+	if !cfg.Level.IsPresent() || cfg.Level.Get() != "debug" || cfg.Level.Default() != "info" {
+		t.Errorf("Expected level debug with default info, but got %v", cfg.Level.Get())
+	}
+}